@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/provider"
+)
+
+// NexusRepositorySettings returns the standard Nexus Repository settings with every authentication
+// strategy: username and password are optional and the settings returned by Settings are added
+func NexusRepositorySettings() []provider.Setting {
+	return withStrategies(provider.NexusRepositorySettings(), "NXRM")
+}
+
+// IQServerSettings returns the standard IQ Server settings with every authentication strategy
+func IQServerSettings() []provider.Setting {
+	return withStrategies(provider.IQServerSettings(), "IQ")
+}
+
+// withStrategies makes username and password optional and appends the strategy settings
+func withStrategies(settings []provider.Setting, prefix string) []provider.Setting {
+	for i := range settings {
+		if settings[i].Name == provider.UsernameSetting || settings[i].Name == provider.PasswordSetting {
+			settings[i].Required = false
+		}
+	}
+	return append(settings, Settings(prefix)...)
+}
+
+// Settings returns the optional user token, token and credential helper settings read from PREFIX_* variables.
+// NexusRepositorySettings and IQServerSettings combine them with the server settings.
+func Settings(prefix string) []provider.Setting {
+	return []provider.Setting{
+		{Name: UserTokenNameCodeSetting, EnvVars: []string{prefix + "_USER_TOKEN_NAME_CODE"}, Sensitive: true},
+		{Name: UserTokenPassCodeSetting, EnvVars: []string{prefix + "_USER_TOKEN_PASS_CODE"}, Sensitive: true},
+		{Name: TokenSetting, EnvVars: []string{prefix + "_TOKEN"}, Sensitive: true},
+		{Name: CredentialHelperSetting, EnvVars: []string{prefix + "_CREDENTIAL_HELPER"}},
+	}
+}
+
+// SchemaAttributes returns the provider schema attributes for the settings returned by Settings
+func SchemaAttributes() map[string]providerschema.Attribute {
+	return map[string]providerschema.Attribute{
+		UserTokenNameCodeSetting: providerschema.StringAttribute{
+			MarkdownDescription: "Name code of a user token, used instead of `username` and `password`",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		UserTokenPassCodeSetting: providerschema.StringAttribute{
+			MarkdownDescription: "Pass code of a user token, used instead of `username` and `password`",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		TokenSetting: providerschema.StringAttribute{
+			MarkdownDescription: "Bearer or API token, used instead of `username` and `password`",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		CredentialHelperSetting: providerschema.StringAttribute{
+			MarkdownDescription: "Command printing JSON credentials to stdout, run instead of configuring credentials directly",
+			Optional:            true,
+		},
+	}
+}
+
+// FromConfig selects the strategy for the resolved provider settings: a credential helper, then a token,
+// then a user token, then username and password, and no authentication when none are set. The selected
+// strategy is validated.
+func FromConfig(config *provider.Config) (Strategy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	token := config.Get(TokenSetting)
+	nameCode, passCode := config.Get(UserTokenNameCodeSetting), config.Get(UserTokenPassCodeSetting)
+	hasUserToken := nameCode != "" || passCode != ""
+
+	var strategy Strategy
+	switch {
+	case config.Get(CredentialHelperSetting) != "" && (token != "" || hasUserToken):
+		diags.AddAttributeError(
+			path.Root(CredentialHelperSetting),
+			"Conflicting Authentication Settings",
+			"Set either credential_helper or a token, not both.",
+		)
+		return nil, diags
+	case config.Get(CredentialHelperSetting) != "":
+		strategy = ParseCredentialHelper(config.Get(CredentialHelperSetting))
+	case token != "" && hasUserToken:
+		diags.AddAttributeError(
+			path.Root(TokenSetting),
+			"Conflicting Authentication Settings",
+			"Set either token or user_token_name_code and user_token_pass_code, not both.",
+		)
+		return nil, diags
+	case token != "":
+		strategy = NewBearerToken(token)
+	case hasUserToken:
+		strategy = NewUserToken(nameCode, passCode)
+	case config.Get(provider.UsernameSetting) != "" || config.Get(provider.PasswordSetting) != "":
+		strategy = NewBasicAuth(config.Get(provider.UsernameSetting), config.Get(provider.PasswordSetting))
+	default:
+		strategy = NoAuth{}
+	}
+
+	diags.Append(strategy.Validate()...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return strategy, diags
+}
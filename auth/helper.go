@@ -0,0 +1,329 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+const (
+	// CredentialHelperSetting is the attribute holding the credential helper command line
+	CredentialHelperSetting = "credential_helper"
+
+	// DefaultCredentialHelperTTL is how long credentials without an expiry are cached
+	DefaultCredentialHelperTTL = 15 * time.Minute
+	// DefaultCredentialHelperTimeout bounds each run of the helper
+	DefaultCredentialHelperTimeout = 30 * time.Second
+	// credentialExpirySkew refreshes credentials shortly before they expire, by at most half their lifetime
+	credentialExpirySkew = 30 * time.Second
+)
+
+// HelperCredentials are the credentials a helper writes to stdout as JSON. Either username and
+// password, user_token_name_code and user_token_pass_code, or token must be set.
+type HelperCredentials struct {
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	UserTokenNameCode string `json:"user_token_name_code"`
+	UserTokenPassCode string `json:"user_token_pass_code"`
+	Token             string `json:"token"`
+	// Scheme precedes the token in the Authorization header, "Bearer" when empty
+	Scheme string `json:"scheme"`
+	// ExpiresAt is an RFC 3339 timestamp after which the credentials are fetched again
+	ExpiresAt string `json:"expires_at"`
+	// ExpiresIn is the number of seconds the credentials are valid for
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// CredentialHelper runs a local command, in the spirit of git and Docker credential helpers, and
+// authenticates with the credentials it prints. The server URL is written to the command's stdin.
+// Credentials are cached until they expire or the server rejects them. Requests that need credentials
+// while the helper runs wait for that run instead of starting their own.
+type CredentialHelper struct {
+	// Command is the executable and its arguments
+	Command []string
+	// TTL is how long credentials without an expiry are cached, DefaultCredentialHelperTTL when zero
+	TTL time.Duration
+	// Timeout bounds each run of the helper, DefaultCredentialHelperTimeout when zero
+	Timeout time.Duration
+
+	mu         sync.Mutex
+	strategy   Strategy
+	expires    time.Time
+	generation uint64
+	fetching   *credentialFetch
+	now        func() time.Time
+}
+
+// credentialFetch is a run of the helper shared by every request waiting for credentials
+type credentialFetch struct {
+	done       chan struct{}
+	strategy   Strategy
+	generation uint64
+	err        error
+}
+
+// NewCredentialHelper creates a strategy that runs command with args
+func NewCredentialHelper(command string, args ...string) *CredentialHelper {
+	return &CredentialHelper{Command: append([]string{command}, args...)}
+}
+
+// ParseCredentialHelper splits a command line such as "vault-nexus-creds --role ci" on whitespace.
+// Wrap commands whose arguments contain spaces in a script.
+func ParseCredentialHelper(commandLine string) *CredentialHelper {
+	return &CredentialHelper{Command: strings.Fields(commandLine)}
+}
+
+// Name returns "credential_helper"
+func (h *CredentialHelper) Name() string {
+	return "credential_helper"
+}
+
+// Apply runs the helper when no credentials are cached or they have expired, then adds them to the request
+func (h *CredentialHelper) Apply(req *http.Request) error {
+	_, err := h.ApplyGeneration(req)
+	return err
+}
+
+// ApplyGeneration applies the credentials like Apply and returns their generation
+func (h *CredentialHelper) ApplyGeneration(req *http.Request) (uint64, error) {
+	strategy, generation, err := h.credentials(req.Context(), serverURL(req))
+	if err != nil {
+		return 0, err
+	}
+	return generation, strategy.Apply(req)
+}
+
+// Invalidate discards the cached credentials so the next request runs the helper again. Credentials
+// that have already been replaced since the given generation are kept.
+func (h *CredentialHelper) Invalidate(generation uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if generation == h.generation {
+		h.strategy = nil
+	}
+}
+
+// Validate checks that the command is set and can be found
+func (h *CredentialHelper) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(h.Command) == 0 || h.Command[0] == "" {
+		requireValue(&diags, CredentialHelperSetting, "", "credential helper")
+		return diags
+	}
+	if _, err := exec.LookPath(h.Command[0]); err != nil {
+		diags.AddAttributeError(
+			path.Root(CredentialHelperSetting),
+			"Credential Helper Not Found",
+			fmt.Sprintf("The credential helper %q cannot be run: %s", h.Command[0], err),
+		)
+	}
+	return diags
+}
+
+// String describes the strategy by its command, which carries no secrets
+func (h *CredentialHelper) String() string {
+	if len(h.Command) == 0 {
+		return "credential helper"
+	}
+	return fmt.Sprintf("credential helper %q", h.Command[0])
+}
+
+// credentials returns the cached strategy and its generation. When they are missing or expired it
+// waits for a run of the helper, starting one unless another request already has.
+func (h *CredentialHelper) credentials(ctx context.Context, server string) (Strategy, uint64, error) {
+	h.mu.Lock()
+	if h.strategy != nil && h.clock().Before(h.expires) {
+		strategy, generation := h.strategy, h.generation
+		h.mu.Unlock()
+		return strategy, generation, nil
+	}
+	fetch := h.fetching
+	if fetch == nil {
+		fetch = &credentialFetch{done: make(chan struct{})}
+		h.fetching = fetch
+		// The run is shared, so it must not end when the request that started it is cancelled
+		go h.fetch(context.WithoutCancel(ctx), server, fetch)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.strategy, fetch.generation, fetch.err
+	case <-ctx.Done():
+		return nil, 0, fmt.Errorf("waiting for credential helper %q: %w", h.Command[0], ctx.Err())
+	}
+}
+
+// fetch runs the helper without holding the lock and stores the credentials as a new generation
+func (h *CredentialHelper) fetch(ctx context.Context, server string, fetch *credentialFetch) {
+	defer close(fetch.done)
+
+	strategy, expires, err := h.fetchCredentials(ctx, server)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fetching = nil
+	if err != nil {
+		fetch.err = err
+		return
+	}
+	h.generation++
+	h.strategy, h.expires = strategy, expires
+	fetch.strategy, fetch.generation = strategy, h.generation
+	tflog.Debug(ctx, "Fetched credentials from credential helper", map[string]interface{}{
+		"command":  h.Command[0],
+		"strategy": strategy.String(),
+		"expires":  expires.Format(time.RFC3339),
+	})
+}
+
+// fetchCredentials runs the helper and returns the strategy for its output and when it expires
+func (h *CredentialHelper) fetchCredentials(ctx context.Context, server string) (Strategy, time.Time, error) {
+	credentials, err := h.run(ctx, server)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	strategy, err := credentials.strategy()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("credential helper %q: %w", h.Command[0], err)
+	}
+	expires, err := credentials.expiry(h.clock(), h.TTL)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("credential helper %q: %w", h.Command[0], err)
+	}
+	return strategy, expires, nil
+}
+
+// run executes the helper and parses its output
+func (h *CredentialHelper) run(ctx context.Context, server string) (*HelperCredentials, error) {
+	if len(h.Command) == 0 || h.Command[0] == "" {
+		return nil, fmt.Errorf("no credential helper command is configured")
+	}
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCredentialHelperTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Stdin = strings.NewReader(server + "\n")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("credential helper %q did not finish within %s", h.Command[0], timeout)
+		}
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("credential helper %q failed: %s", h.Command[0], redact.String(message))
+	}
+
+	var credentials HelperCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+		return nil, fmt.Errorf("credential helper %q printed invalid JSON: %w", h.Command[0], err)
+	}
+	return &credentials, nil
+}
+
+// clock returns the current time
+func (h *CredentialHelper) clock() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// strategy builds the strategy for the credentials, registering them with the redaction registry.
+// Problems are described in terms of the helper output, not the provider attributes.
+func (c *HelperCredentials) strategy() (Strategy, error) {
+	switch {
+	case c.Token != "":
+		if strings.ContainsAny(c.Token, " \t\r\n") {
+			return nil, fmt.Errorf("the token in the output contains whitespace")
+		}
+		bearer := NewBearerToken(c.Token)
+		bearer.Scheme = c.Scheme
+		return bearer, nil
+	case c.UserTokenNameCode != "" || c.UserTokenPassCode != "":
+		if c.UserTokenNameCode == "" || c.UserTokenPassCode == "" {
+			return nil, fmt.Errorf("the output must set both user_token_name_code and user_token_pass_code")
+		}
+		return NewUserToken(c.UserTokenNameCode, c.UserTokenPassCode), nil
+	case c.Username != "" || c.Password != "":
+		if c.Username == "" || c.Password == "" {
+			return nil, fmt.Errorf("the output must set both username and password")
+		}
+		if strings.Contains(c.Username, ":") {
+			return nil, fmt.Errorf("the username in the output cannot contain a colon (:) when using HTTP basic authentication")
+		}
+		return NewBasicAuth(c.Username, c.Password), nil
+	default:
+		return nil, fmt.Errorf("output contains no token, user token or username and password")
+	}
+}
+
+// expiry returns when the credentials must be fetched again
+func (c *HelperCredentials) expiry(now time.Time, ttl time.Duration) (time.Time, error) {
+	var expires time.Time
+	switch {
+	case c.ExpiresAt != "":
+		var err error
+		if expires, err = time.Parse(time.RFC3339, c.ExpiresAt); err != nil {
+			return time.Time{}, fmt.Errorf("expires_at %q is not an RFC 3339 timestamp", c.ExpiresAt)
+		}
+		// Caching credentials that are already expired would run the helper on every request
+		if !expires.After(now) {
+			return time.Time{}, fmt.Errorf("expires_at %q is not in the future", c.ExpiresAt)
+		}
+	case c.ExpiresIn > 0:
+		expires = now.Add(time.Duration(c.ExpiresIn) * time.Second)
+	default:
+		if ttl <= 0 {
+			ttl = DefaultCredentialHelperTTL
+		}
+		return now.Add(ttl), nil
+	}
+	// Short-lived credentials would otherwise be refreshed on every request
+	skew := min(credentialExpirySkew, max(expires.Sub(now)/2, 0))
+	return expires.Add(-skew), nil
+}
+
+// serverURL returns the scheme and host the request is sent to
+func serverURL(req *http.Request) string {
+	if req.URL == nil {
+		return ""
+	}
+	return req.URL.Scheme + "://" + req.URL.Host
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/client"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// The credential helper refreshes its credentials when AuthMiddleware sees a 401
+var _ client.RefreshableAuthenticator = (*CredentialHelper)(nil)
+
+// stubHelper writes a shell script that records its stdin and prints output, with COUNT replaced
+// by the number of times it has run
+func stubHelper(t *testing.T, output string) (command string, dir string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub credential helpers are shell scripts")
+	}
+	dir = t.TempDir()
+	command = filepath.Join(dir, "helper")
+	script := `#!/bin/sh
+cat > "` + dir + `/stdin"
+echo run >> "` + dir + `/runs"
+COUNT=$(wc -l < "` + dir + `/runs" | tr -d ' ')
+` + output + "\n"
+	if err := os.WriteFile(command, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return command, dir
+}
+
+func helperRuns(t *testing.T, dir string) int {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, "runs"))
+	if err != nil {
+		return 0
+	}
+	return strings.Count(string(content), "run")
+}
+
+func TestCredentialHelper_CachesCredentials(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"username": "ci-user", "password": "helper-secret-'$COUNT'"}'`)
+	helper := NewCredentialHelper(command)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com/service/rest/v1/status", nil)
+		if err := helper.Apply(req); err != nil {
+			t.Fatalf("Apply returned unexpected error: %v", err)
+		}
+		if username, password, _ := req.BasicAuth(); username != "ci-user" || password != "helper-secret-1" {
+			t.Fatalf("BasicAuth() = %s, %s, expected the first credentials", username, password)
+		}
+	}
+
+	if runs := helperRuns(t, dir); runs != 1 {
+		t.Fatalf("helper ran %d times, expected 1", runs)
+	}
+	if stdin, _ := os.ReadFile(filepath.Join(dir, "stdin")); strings.TrimSpace(string(stdin)) != "https://nexus.example.com" {
+		t.Fatalf("stdin = %q, expected the server URL", stdin)
+	}
+	if actual := redact.String("helper-secret-1"); actual != redact.Mask {
+		t.Fatalf("redact.String() = %q, expected the password to be registered", actual)
+	}
+}
+
+func TestCredentialHelper_Expiry(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"token": "token-value-'$COUNT'", "expires_in": 300}'`)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	helper := NewCredentialHelper(command)
+	helper.now = func() time.Time { return now }
+
+	apply := func() string {
+		req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)
+		if err := helper.Apply(req); err != nil {
+			t.Fatalf("Apply returned unexpected error: %v", err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	if actual := apply(); actual != "Bearer token-value-1" {
+		t.Fatalf("Authorization = %q, expected 'Bearer token-value-1'", actual)
+	}
+	now = now.Add(4 * time.Minute)
+	if actual := apply(); actual != "Bearer token-value-1" {
+		t.Fatalf("Authorization = %q, expected the cached token", actual)
+	}
+	now = now.Add(time.Minute)
+	if actual := apply(); actual != "Bearer token-value-2" {
+		t.Fatalf("Authorization = %q, expected a refreshed token shortly before expiry", actual)
+	}
+	if runs := helperRuns(t, dir); runs != 2 {
+		t.Fatalf("helper ran %d times, expected 2", runs)
+	}
+}
+
+func TestCredentialHelper_ShortExpiry(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"token": "token-value-'$COUNT'", "expires_in": 20}'`)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	helper := NewCredentialHelper(command)
+	helper.now = func() time.Time { return now }
+
+	apply := func() string {
+		req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)
+		if err := helper.Apply(req); err != nil {
+			t.Fatalf("Apply returned unexpected error: %v", err)
+		}
+		return req.Header.Get("Authorization")
+	}
+
+	apply()
+	now = now.Add(9 * time.Second)
+	if actual := apply(); actual != "Bearer token-value-1" {
+		t.Fatalf("Authorization = %q, expected credentials shorter lived than the skew to be cached", actual)
+	}
+	now = now.Add(time.Second)
+	if actual := apply(); actual != "Bearer token-value-2" {
+		t.Fatalf("Authorization = %q, expected a refresh after half the lifetime", actual)
+	}
+	if runs := helperRuns(t, dir); runs != 2 {
+		t.Fatalf("helper ran %d times, expected 2", runs)
+	}
+}
+
+func TestCredentialHelper_ConcurrentRequestsShareOneRun(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `sleep 0.2; echo '{"token": "token-value-'$COUNT'"}'`)
+	helper := NewCredentialHelper(command)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)
+			if err := helper.Apply(req); err != nil {
+				t.Errorf("Apply returned unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if runs := helperRuns(t, dir); runs != 1 {
+		t.Fatalf("helper ran %d times, expected 1", runs)
+	}
+}
+
+func TestCredentialHelper_CancelledWhileRunning(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, _ := stubHelper(t, `sleep 1; echo '{"token": "token-value"}'`)
+	helper := NewCredentialHelper(command)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := helper.Apply(httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil).WithContext(ctx))
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Apply() error = %v, expected the request deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("Apply returned after %s, expected it not to wait for the helper", elapsed)
+	}
+
+	// The run continues for the requests that come after
+	if err := helper.Apply(httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)); err != nil {
+		t.Fatalf("Apply returned unexpected error: %v", err)
+	}
+}
+
+func TestCredentialHelper_InvalidateKeepsNewerCredentials(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"token": "token-value-'$COUNT'"}'`)
+	helper := NewCredentialHelper(command)
+	apply := func() uint64 {
+		generation, err := helper.ApplyGeneration(httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil))
+		if err != nil {
+			t.Fatalf("ApplyGeneration returned unexpected error: %v", err)
+		}
+		return generation
+	}
+
+	rejected := apply()
+	helper.Invalidate(rejected)
+	refreshed := apply()
+	// A second request rejected with the same credentials must not discard the refreshed ones
+	helper.Invalidate(rejected)
+	if generation := apply(); generation != refreshed || refreshed == rejected {
+		t.Fatalf("generations = %d, %d, %d, expected the refreshed credentials to be kept", rejected, refreshed, generation)
+	}
+	if runs := helperRuns(t, dir); runs != 2 {
+		t.Fatalf("helper ran %d times, expected 2", runs)
+	}
+}
+
+func TestCredentialHelper_ConcurrentUnauthorizedRefreshOnce(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"token": "token-value-'$COUNT'"}'`)
+	var rejected sync.WaitGroup
+	rejected.Add(5)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-value-1" {
+			// Answer 401 once every request holds the first credentials
+			rejected.Done()
+			rejected.Wait()
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient, err := client.NewBuilder().WithLogging(false).WithAuth(NewCredentialHelper(command)).Build()
+	if err != nil {
+		t.Fatalf("Build returned unexpected error: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			httpResponse, err := httpClient.Get(server.URL)
+			if err != nil {
+				t.Errorf("Get returned unexpected error: %v", err)
+				return
+			}
+			httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusNoContent {
+				t.Errorf("StatusCode = %d, expected the retried request to succeed", httpResponse.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if runs := helperRuns(t, dir); runs != 2 {
+		t.Fatalf("helper ran %d times, expected 2", runs)
+	}
+}
+
+func TestCredentialHelper_RefreshesOnUnauthorized(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	command, dir := stubHelper(t, `echo '{"token": "token-value-'$COUNT'"}'`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-value-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient, err := client.NewBuilder().WithLogging(false).WithAuth(NewCredentialHelper(command)).Build()
+	if err != nil {
+		t.Fatalf("Build returned unexpected error: %v", err)
+	}
+	httpResponse, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusNoContent {
+		t.Fatalf("StatusCode = %d, expected the retried request to succeed", httpResponse.StatusCode)
+	}
+	if runs := helperRuns(t, dir); runs != 2 {
+		t.Fatalf("helper ran %d times, expected 2", runs)
+	}
+}
+
+func TestCredentialHelper_Errors(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	redact.Add("leaked-secret")
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{"failure", `echo "vault login failed for leaked-secret" >&2; exit 1`, `failed: vault login failed for ***`},
+		{"invalid json", `echo 'password=leaked-secret'`, "printed invalid JSON"},
+		{"no credentials", `echo '{"expires_in": 60}'`, "contains no token"},
+		{"incomplete", `echo '{"username": "ci-user"}'`, "the output must set both username and password"},
+		{"incomplete user token", `echo '{"user_token_pass_code": "leaked-secret"}'`, "must set both user_token_name_code and user_token_pass_code"},
+		{"invalid expiry", `echo '{"token": "abc", "expires_at": "tomorrow"}'`, "not an RFC 3339 timestamp"},
+		{"past expiry", `echo '{"token": "abc", "expires_at": "2020-01-01T00:00:00Z"}'`, "not in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, _ := stubHelper(t, tt.output)
+			err := NewCredentialHelper(command).Apply(httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("Apply() error = %v, expected it to contain %q", err, tt.expected)
+			}
+			if strings.Contains(err.Error(), "leaked-secret") {
+				t.Fatalf("Apply() error = %v reveals a secret", err)
+			}
+		})
+	}
+}
+
+func TestCredentialHelper_Timeout(t *testing.T) {
+	command, _ := stubHelper(t, `exec sleep 5`)
+	helper := NewCredentialHelper(command)
+	helper.Timeout = 50 * time.Millisecond
+
+	err := helper.Apply(httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil))
+	if err == nil || !strings.Contains(err.Error(), "did not finish within 50ms") {
+		t.Fatalf("Apply() error = %v, expected a timeout", err)
+	}
+}
+
+func TestCredentialHelper_Validate(t *testing.T) {
+	command, _ := stubHelper(t, `echo '{}'`)
+	if diags := ParseCredentialHelper(command + " --role ci").Validate(); diags.HasError() {
+		t.Fatalf("Validate() returned unexpected errors: %v", diags)
+	}
+	if diags := ParseCredentialHelper("").Validate(); !diags.HasError() {
+		t.Fatal("Validate() should reject an empty command")
+	}
+	diags := NewCredentialHelper(filepath.Join(t.TempDir(), "missing")).Validate()
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Credential Helper Not Found" {
+		t.Fatalf("Validate() = %v, expected a missing helper error", diags)
+	}
+}
+
+func TestFromConfig_CredentialHelper(t *testing.T) {
+	command, _ := stubHelper(t, `echo '{}'`)
+	strategy, diags := FromConfig(testLoad(t, map[string]string{"NXRM_CREDENTIAL_HELPER": command, "NXRM_USERNAME": "admin"}))
+	if diags.HasError() || strategy.Name() != "credential_helper" {
+		t.Fatalf("FromConfig() = %v, %v, expected the credential helper", strategy, diags)
+	}
+
+	_, diags = FromConfig(testLoad(t, map[string]string{"NXRM_CREDENTIAL_HELPER": command, "NXRM_TOKEN": "api-token"}))
+	if !diags.HasError() {
+		t.Fatal("FromConfig() should reject a credential helper combined with a token")
+	}
+}
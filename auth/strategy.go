@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth provides the authentication strategies shared by Sonatype providers
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/provider"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+const (
+	// UserTokenNameCodeSetting is the attribute holding the name code of a Sonatype user token
+	UserTokenNameCodeSetting = "user_token_name_code"
+	// UserTokenPassCodeSetting is the attribute holding the pass code of a Sonatype user token
+	UserTokenPassCodeSetting = "user_token_pass_code"
+	// TokenSetting is the attribute holding a bearer or API token
+	TokenSetting = "token"
+)
+
+// Strategy authenticates requests to a Sonatype server. Strategies satisfy client.Authenticator.
+type Strategy interface {
+	// Name returns a stable name for the strategy, such as "basic"
+	Name() string
+	// Apply adds the credentials to the request
+	Apply(req *http.Request) error
+	// Validate checks the strategy's attributes, reporting problems on the provider attributes
+	Validate() diag.Diagnostics
+	// String describes the strategy for logs without revealing secrets
+	String() string
+}
+
+// BasicAuth authenticates with HTTP basic auth
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// NewBasicAuth creates a basic auth strategy and registers the password with the redaction registry
+func NewBasicAuth(username string, password string) *BasicAuth {
+	registerBasicCredentials(username, password)
+	return &BasicAuth{Username: username, Password: password}
+}
+
+// Name returns "basic"
+func (a *BasicAuth) Name() string {
+	return "basic"
+}
+
+// Apply sets the Authorization header
+func (a *BasicAuth) Apply(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// Validate checks that the username and password are set and the username contains no colon
+func (a *BasicAuth) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	requireValue(&diags, provider.UsernameSetting, a.Username, "username")
+	requireValue(&diags, provider.PasswordSetting, a.Password, "password")
+	if strings.Contains(a.Username, ":") {
+		diags.AddAttributeError(
+			path.Root(provider.UsernameSetting),
+			"Invalid Username",
+			"The username cannot contain a colon (:) when using HTTP basic authentication.",
+		)
+	}
+	return diags
+}
+
+// String describes the strategy with the password masked
+func (a *BasicAuth) String() string {
+	return fmt.Sprintf("basic auth as %q (password %s)", a.Username, redact.Mask)
+}
+
+// UserToken authenticates with a Sonatype user token, sent as basic auth with the name code as
+// username and the pass code as password
+type UserToken struct {
+	NameCode string
+	PassCode string
+}
+
+// NewUserToken creates a user token strategy and registers both codes with the redaction registry
+func NewUserToken(nameCode string, passCode string) *UserToken {
+	redact.Add(nameCode)
+	registerBasicCredentials(nameCode, passCode)
+	return &UserToken{NameCode: nameCode, PassCode: passCode}
+}
+
+// Name returns "user_token"
+func (a *UserToken) Name() string {
+	return "user_token"
+}
+
+// Apply sets the Authorization header
+func (a *UserToken) Apply(req *http.Request) error {
+	req.SetBasicAuth(a.NameCode, a.PassCode)
+	return nil
+}
+
+// Validate checks that both codes are set
+func (a *UserToken) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	requireValue(&diags, UserTokenNameCodeSetting, a.NameCode, "user token name code")
+	requireValue(&diags, UserTokenPassCodeSetting, a.PassCode, "user token pass code")
+	return diags
+}
+
+// String describes the strategy with both codes masked
+func (a *UserToken) String() string {
+	return fmt.Sprintf("user token (name code %s, pass code %s)", redact.Mask, redact.Mask)
+}
+
+// BearerToken authenticates with a token in the Authorization header
+type BearerToken struct {
+	Token string
+	// Scheme precedes the token in the Authorization header, "Bearer" when empty
+	Scheme string
+}
+
+// NewBearerToken creates a bearer token strategy and registers the token with the redaction registry
+func NewBearerToken(token string) *BearerToken {
+	redact.Add(token)
+	return &BearerToken{Token: token}
+}
+
+// Name returns "bearer"
+func (a *BearerToken) Name() string {
+	return "bearer"
+}
+
+// Apply sets the Authorization header
+func (a *BearerToken) Apply(req *http.Request) error {
+	scheme := a.Scheme
+	if scheme == "" {
+		scheme = "Bearer"
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", scheme, a.Token))
+	return nil
+}
+
+// Validate checks that the token is set and contains no whitespace
+func (a *BearerToken) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	requireValue(&diags, TokenSetting, a.Token, "token")
+	if strings.ContainsAny(a.Token, " \t\r\n") {
+		diags.AddAttributeError(
+			path.Root(TokenSetting),
+			"Invalid Token",
+			"The token cannot contain whitespace. Check that it was copied without surrounding spaces or line breaks.",
+		)
+	}
+	return diags
+}
+
+// String describes the strategy with the token masked
+func (a *BearerToken) String() string {
+	return fmt.Sprintf("bearer token %s", redact.Mask)
+}
+
+// NoAuth sends requests without credentials, e.g. to anonymous endpoints
+type NoAuth struct{}
+
+// Name returns "none"
+func (NoAuth) Name() string {
+	return "none"
+}
+
+// Apply leaves the request unchanged
+func (NoAuth) Apply(_ *http.Request) error {
+	return nil
+}
+
+// Validate always succeeds
+func (NoAuth) Validate() diag.Diagnostics {
+	return nil
+}
+
+// String describes the strategy
+func (NoAuth) String() string {
+	return "no authentication"
+}
+
+// requireValue adds an attribute error when a credential is empty
+func requireValue(diags *diag.Diagnostics, attribute string, value string, description string) {
+	if value != "" {
+		return
+	}
+	diags.AddAttributeError(
+		path.Root(attribute),
+		fmt.Sprintf("Missing %s", description),
+		fmt.Sprintf("The %s must be set in the %q attribute, the environment or a credentials profile.", description, attribute),
+	)
+}
+
+// registerBasicCredentials registers the password and the encoded Authorization header value with the redaction registry
+func registerBasicCredentials(username string, password string) {
+	if password == "" {
+		return
+	}
+	redact.Add(password, base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/client"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/provider"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// Strategies are passed to client.Builder.WithAuth
+var _ client.Authenticator = Strategy(nil)
+
+func testLoad(t *testing.T, env map[string]string) *provider.Config {
+	t.Helper()
+	loader := provider.NewLoader(NexusRepositorySettings()...)
+	loader.LookupEnv = func(key string) (string, bool) {
+		if key == "NXRM_URL" {
+			return "https://nexus.example.com", true
+		}
+		value, ok := env[key]
+		return value, ok
+	}
+	loader.DefaultCredentialsFile = ""
+
+	cfg, diags := loader.Load(context.Background(), tfsdk.Config{})
+	if diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+	return cfg
+}
+
+func TestStrategies_Apply(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	tests := []struct {
+		strategy Strategy
+		expected string
+	}{
+		{NewBasicAuth("admin", "s3cret-pass"), "Basic YWRtaW46czNjcmV0LXBhc3M="},
+		{NewUserToken("name-code", "pass-code"), "Basic bmFtZS1jb2RlOnBhc3MtY29kZQ=="},
+		{NewBearerToken("api-token-value"), "Bearer api-token-value"},
+		{&BearerToken{Token: "api-token-value", Scheme: "Token"}, "Token api-token-value"},
+		{NoAuth{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy.Name(), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)
+			if err := tt.strategy.Apply(req); err != nil {
+				t.Fatalf("Apply returned unexpected error: %v", err)
+			}
+			if actual := req.Header.Get("Authorization"); actual != tt.expected {
+				t.Fatalf("Authorization = %q, expected %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStrategies_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+		paths    []path.Path
+	}{
+		{"basic", &BasicAuth{Username: "admin", Password: "secret"}, nil},
+		{"basic missing password", &BasicAuth{Username: "admin"}, []path.Path{path.Root(provider.PasswordSetting)}},
+		{"basic colon", &BasicAuth{Username: "ad:min", Password: "secret"}, []path.Path{path.Root(provider.UsernameSetting)}},
+		{"user token missing both", &UserToken{}, []path.Path{path.Root(UserTokenNameCodeSetting), path.Root(UserTokenPassCodeSetting)}},
+		{"bearer whitespace", &BearerToken{Token: "abc def"}, []path.Path{path.Root(TokenSetting)}},
+		{"none", NoAuth{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := tt.strategy.Validate()
+			if len(diags) != len(tt.paths) {
+				t.Fatalf("Validate() returned %d diagnostics, expected %d: %v", len(diags), len(tt.paths), diags)
+			}
+			for i, d := range diags {
+				withPath, ok := d.(interface{ Path() path.Path })
+				if !ok || !withPath.Path().Equal(tt.paths[i]) {
+					t.Fatalf("diagnostic %d is not on %s: %v", i, tt.paths[i], d)
+				}
+			}
+		})
+	}
+}
+
+func TestStrategies_StringAndRedaction(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	strategies := []Strategy{
+		NewBasicAuth("admin", "basic-secret"),
+		NewUserToken("token-name", "token-pass"),
+		NewBearerToken("bearer-secret"),
+	}
+
+	for _, strategy := range strategies {
+		description := strategy.String()
+		for _, secret := range []string{"basic-secret", "token-name", "token-pass", "bearer-secret"} {
+			if strings.Contains(description, secret) {
+				t.Fatalf("String() = %q reveals %q", description, secret)
+			}
+		}
+		if !strings.Contains(description, redact.Mask) {
+			t.Fatalf("String() = %q, expected a masked credential", description)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "https://nexus.example.com", nil)
+	_ = strategies[0].Apply(req)
+	if actual := redact.String(req.Header.Get("Authorization")); actual != "Basic ***" {
+		t.Fatalf("redact.String() = %q, expected the encoded credentials to be registered", actual)
+	}
+	if actual := redact.String("token-name bearer-secret"); actual != "*** ***" {
+		t.Fatalf("redact.String() = %q, expected the token values to be registered", actual)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+		errors   bool
+	}{
+		{"token", map[string]string{"NXRM_TOKEN": "api-token", "NXRM_USERNAME": "admin", "NXRM_PASSWORD": "secret"}, "bearer", false},
+		{"user token", map[string]string{"NXRM_USER_TOKEN_NAME_CODE": "name", "NXRM_USER_TOKEN_PASS_CODE": "pass"}, "user_token", false},
+		{"basic", map[string]string{"NXRM_USERNAME": "admin", "NXRM_PASSWORD": "secret"}, "basic", false},
+		{"none", map[string]string{}, "none", false},
+		{"incomplete basic", map[string]string{"NXRM_USERNAME": "admin"}, "", true},
+		{"conflicting", map[string]string{"NXRM_TOKEN": "api-token", "NXRM_USER_TOKEN_NAME_CODE": "name"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, diags := FromConfig(testLoad(t, tt.env))
+			if diags.HasError() != tt.errors {
+				t.Fatalf("FromConfig() diagnostics = %v, expected errors: %t", diags, tt.errors)
+			}
+			if tt.errors {
+				return
+			}
+			if strategy.Name() != tt.expected {
+				t.Fatalf("FromConfig() = %s, expected %s", strategy.Name(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerSettings_CredentialsOptional(t *testing.T) {
+	for name, settings := range map[string][]provider.Setting{
+		"nexus repository": NexusRepositorySettings(),
+		"iq server":        IQServerSettings(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			names := map[string]provider.Setting{}
+			for _, setting := range settings {
+				names[setting.Name] = setting
+			}
+			if names[provider.UsernameSetting].Required || names[provider.PasswordSetting].Required {
+				t.Fatal("username and password should be optional when other strategies are offered")
+			}
+			if !names[provider.URLSetting].Required {
+				t.Fatal("url should stay required")
+			}
+			if _, ok := names[TokenSetting]; !ok {
+				t.Fatalf("settings %v should include %s", settings, TokenSetting)
+			}
+		})
+	}
+}
+
+func TestStrategy_WithBuilder(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	var received string
+	httpClient, err := client.NewBuilder().
+		WithLogging(false).
+		WithAuth(NewBearerToken("builder-token")).
+		WithBaseTransport(client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			received = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned unexpected error: %v", err)
+	}
+
+	httpResponse, err := httpClient.Get("https://nexus.example.com/service/rest/v1/status")
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	httpResponse.Body.Close()
+	if received != "Bearer builder-token" {
+		t.Fatalf("Authorization = %q, expected 'Bearer builder-token'", received)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Builder assembles the http.Client shared by a provider's resources. Requests pass through the
+// middlewares in this order: custom middlewares, User-Agent, redaction, authentication, retry,
+// rate limiting, per-attempt timeout and logging, then the TLS and proxy aware transport.
+type Builder struct {
+	tls            TLSConfig
+	proxy          ProxyConfig
+	userAgent      string
+	authenticator  Authenticator
+	retry          *RetryConfig
+	limiter        *RateLimiter
+	attemptTimeout time.Duration
+	timeout        time.Duration
+	logging        bool
+	middlewares    []Middleware
+	base           http.RoundTripper
+}
+
+// NewBuilder creates a builder that logs requests and honours the proxy environment variables
+func NewBuilder() *Builder {
+	return &Builder{logging: true}
+}
+
+// WithTLS sets the CA bundle, client certificate, minimum version and verification of the server certificate
+func (b *Builder) WithTLS(config TLSConfig) *Builder {
+	b.tls = config
+	return b
+}
+
+// WithProxy sets the proxy and the hosts reached directly
+func (b *Builder) WithProxy(config ProxyConfig) *Builder {
+	b.proxy = config
+	return b
+}
+
+// WithUserAgent sets the User-Agent header, e.g. "terraform-provider-sonatyperepo/1.2.3"
+func (b *Builder) WithUserAgent(userAgent string) *Builder {
+	b.userAgent = userAgent
+	return b
+}
+
+// WithAuth adds credentials to every request
+func (b *Builder) WithAuth(authenticator Authenticator) *Builder {
+	b.authenticator = authenticator
+	return b
+}
+
+// WithRetry retries failed requests. See RetryTransport.
+func (b *Builder) WithRetry(config RetryConfig) *Builder {
+	b.retry = &config
+	return b
+}
+
+// WithRateLimiter sends every request, including retries, through the limiter
+func (b *Builder) WithRateLimiter(limiter *RateLimiter) *Builder {
+	b.limiter = limiter
+	return b
+}
+
+// WithAttemptTimeout bounds each attempt, so a retried request can take longer in total
+func (b *Builder) WithAttemptTimeout(timeout time.Duration) *Builder {
+	b.attemptTimeout = timeout
+	return b
+}
+
+// WithTimeout bounds each request including all of its retries, like http.Client.Timeout
+func (b *Builder) WithTimeout(timeout time.Duration) *Builder {
+	b.timeout = timeout
+	return b
+}
+
+// WithLogging enables or disables request and response logging
+func (b *Builder) WithLogging(enabled bool) *Builder {
+	b.logging = enabled
+	return b
+}
+
+// WithBaseTransport replaces the TLS and proxy aware transport, e.g. in tests
+func (b *Builder) WithBaseTransport(base http.RoundTripper) *Builder {
+	b.base = base
+	return b
+}
+
+// Use adds custom middlewares that see each request before the built in ones
+func (b *Builder) Use(middlewares ...Middleware) *Builder {
+	b.middlewares = append(b.middlewares, middlewares...)
+	return b
+}
+
+// Build creates the client. The result can be handed to generated OpenAPI clients and to BaseResourceConfig.
+func (b *Builder) Build() (*http.Client, error) {
+	transport, err := b.Transport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: b.timeout}, nil
+}
+
+// Transport creates the transport with every middleware applied
+func (b *Builder) Transport() (http.RoundTripper, error) {
+	base := b.base
+	if base == nil {
+		transport, err := b.baseTransport()
+		if err != nil {
+			return nil, err
+		}
+		base = transport
+	}
+
+	middlewares := append([]Middleware{}, b.middlewares...)
+	if b.userAgent != "" {
+		middlewares = append(middlewares, UserAgentMiddleware(b.userAgent))
+	}
+	middlewares = append(middlewares, RedactionMiddleware())
+	if b.authenticator != nil {
+		middlewares = append(middlewares, AuthMiddleware(b.authenticator))
+	}
+	if b.retry != nil {
+		middlewares = append(middlewares, RetryMiddleware(*b.retry))
+	}
+	if b.limiter != nil {
+		middlewares = append(middlewares, RateLimitMiddleware(b.limiter))
+	}
+	if b.attemptTimeout > 0 {
+		middlewares = append(middlewares, TimeoutMiddleware(b.attemptTimeout))
+	}
+	if b.logging {
+		middlewares = append(middlewares, LoggingMiddleware())
+	}
+	return Chain(base, middlewares...), nil
+}
+
+// baseTransport clones http.DefaultTransport with the TLS and proxy settings applied
+func (b *Builder) baseTransport() (*http.Transport, error) {
+	tlsConfig, err := b.tls.Build()
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+	proxy, err := b.proxy.ProxyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("configuring proxy: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy
+	return transport, nil
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+type headerAuthenticator struct {
+	calls int
+}
+
+func (a *headerAuthenticator) Apply(req *http.Request) error {
+	a.calls++
+	req.Header.Set("Authorization", "Bearer test-token")
+	return nil
+}
+
+type refreshingAuthenticator struct {
+	token       string
+	generation  uint64
+	invalidated int
+}
+
+func (a *refreshingAuthenticator) Apply(req *http.Request) error {
+	_, err := a.ApplyGeneration(req)
+	return err
+}
+
+func (a *refreshingAuthenticator) ApplyGeneration(req *http.Request) (uint64, error) {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return a.generation, nil
+}
+
+func (a *refreshingAuthenticator) Invalidate(generation uint64) {
+	if generation != a.generation {
+		return
+	}
+	a.invalidated++
+	a.generation++
+	a.token = "fresh-token"
+}
+
+func echoHeadersServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Header.Get("User-Agent")+"|"+r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBuilder_Middlewares(t *testing.T) {
+	server := echoHeadersServer(t)
+	authenticator := &headerAuthenticator{}
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":"+req.Header.Get("Authorization"))
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	httpClient, err := NewBuilder().
+		WithUserAgent("terraform-provider-test/1.0.0").
+		WithAuth(authenticator).
+		WithRetry(testRetryConfig()).
+		WithTimeout(5*time.Second).
+		Use(record("first"), record("second")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if string(body) != "terraform-provider-test/1.0.0|Bearer test-token" {
+		t.Fatalf("server received %q, expected the User-Agent and Authorization headers", body)
+	}
+	if strings.Join(order, ",") != "first:,second:" {
+		t.Fatalf("custom middlewares ran as %v, expected in order before authentication", order)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Fatal("authentication should not modify the caller's request")
+	}
+	if httpClient.Timeout != 5*time.Second || authenticator.calls != 1 {
+		t.Fatalf("unexpected client: timeout %s, %d authentications", httpClient.Timeout, authenticator.calls)
+	}
+}
+
+func TestBuilder_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	untrusted, _ := NewBuilder().Build()
+	if _, err := untrusted.Get(server.URL); err == nil {
+		t.Fatal("expected the test server certificate to be untrusted by default")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, config := range map[string]TLSConfig{
+		"ca file":  {CACertFile: caFile},
+		"ca pem":   {CACertPEM: caPEM, MinVersion: "1.3"},
+		"insecure": {InsecureSkipVerify: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			httpClient, err := NewBuilder().WithTLS(config).Build()
+			if err != nil {
+				t.Fatalf("Build returned unexpected error: %v", err)
+			}
+			resp, err := httpClient.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Get returned unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestTLSConfig_Invalid(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]TLSConfig{
+		"min version":      {MinVersion: "1.4"},
+		"missing ca":       {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"invalid ca":       {CACertFile: notPEM},
+		"key without cert": {ClientKeyFile: "client.key"},
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewBuilder().WithTLS(config).Build(); err == nil {
+				t.Fatal("Build should reject the TLS configuration")
+			}
+		})
+	}
+}
+
+func TestProxyConfig(t *testing.T) {
+	proxy, err := ProxyConfig{URL: "http://proxy.example.com:3128", NoProxy: "internal.example.com,10.0.0.0/8"}.ProxyFunc()
+	if err != nil {
+		t.Fatalf("ProxyFunc returned unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		"https://nexus.example.com/service/rest":         "http://proxy.example.com:3128",
+		"https://nexus.internal.example.com/service":     "",
+		"http://10.1.2.3:8081/service/rest/v1/status":    "",
+		"http://iq.example.org:8070/api/v2/applications": "http://proxy.example.com:3128",
+	}
+	for target, expected := range tests {
+		req := &http.Request{URL: mustParseURL(t, target)}
+		proxyURL, err := proxy(req)
+		if err != nil {
+			t.Fatalf("proxy(%s) returned unexpected error: %v", target, err)
+		}
+		if actual := urlString(proxyURL); actual != expected {
+			t.Errorf("proxy(%s) = %q, expected %q", target, actual, expected)
+		}
+	}
+
+	if disabled, _ := (ProxyConfig{URL: "http://proxy.example.com:3128", Disabled: true}).ProxyFunc(); disabled != nil {
+		t.Fatal("a disabled proxy should not return a proxy function")
+	}
+}
+
+func TestTimeoutMiddleware_BodyReadable(t *testing.T) {
+	server := echoHeadersServer(t)
+	httpClient := &http.Client{Transport: Chain(nil, TimeoutMiddleware(time.Second))}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("reading the body after RoundTrip returned failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestAuthMiddleware_RefreshesOnUnauthorized(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	authenticator := &refreshingAuthenticator{token: "stale-token"}
+	httpClient := &http.Client{Transport: Chain(nil, AuthMiddleware(authenticator))}
+	resp, err := httpClient.Post(server.URL, "application/json", strings.NewReader(`{"name":"maven-releases"}`))
+	if err != nil {
+		t.Fatalf("Post returned unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated || authenticator.invalidated != 1 {
+		t.Fatalf("StatusCode = %d after %d invalidations, expected 201 after 1", resp.StatusCode, authenticator.invalidated)
+	}
+	if len(bodies) != 2 || bodies[1] != bodies[0] {
+		t.Fatalf("bodies = %q, expected the body to be replayed", bodies)
+	}
+
+	plain := &http.Client{Transport: Chain(nil, AuthMiddleware(&headerAuthenticator{}))}
+	resp, err = plain.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("StatusCode = %d, expected a plain authenticator not to retry", resp.StatusCode)
+	}
+}
+
+func TestMaskHeaders(t *testing.T) {
+	redact.Add("nexus-password")
+	t.Cleanup(redact.Default.Reset)
+
+	masked := maskHeaders(http.Header{
+		"Authorization": []string{"Basic YWRtaW46YWRtaW4xMjM="},
+		"X-Echo":        []string{"nexus-password"},
+		"Content-Type":  []string{"application/json"},
+	})
+	if masked["Authorization"] != redact.Mask || masked["X-Echo"] != redact.Mask || masked["Content-Type"] != "application/json" {
+		t.Fatalf("maskHeaders() = %v, expected sensitive values masked", masked)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
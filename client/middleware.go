@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// Middleware wraps a transport with additional behaviour
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Authenticator adds credentials to outgoing requests
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// RefreshableAuthenticator is an Authenticator with cached credentials that the server may reject
+// before they expire. AuthMiddleware invalidates them and retries once when a request gets a 401.
+type RefreshableAuthenticator interface {
+	Authenticator
+	// ApplyGeneration applies the credentials like Apply and returns their generation, which changes
+	// every time new credentials are fetched
+	ApplyGeneration(req *http.Request) (uint64, error)
+	// Invalidate discards the cached credentials if they are still of the given generation, so that
+	// requests rejected at the same time cause a single refresh
+	Invalidate(generation uint64)
+}
+
+// SensitiveHeaders are masked when requests and responses are logged
+var SensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "NX-ANTI-CSRF-TOKEN"}
+
+// Chain applies middlewares to base so that the first middleware sees each request first
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+// UserAgentMiddleware sets the User-Agent header on requests that do not already have one
+func UserAgentMiddleware(userAgent string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if userAgent == "" || req.Header.Get("User-Agent") != "" {
+				return next.RoundTrip(req)
+			}
+			req = req.Clone(req.Context())
+			req.Header.Set("User-Agent", userAgent)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// AuthMiddleware applies the authenticator to a copy of every request. A RefreshableAuthenticator is
+// invalidated and the request sent once more when the server answers 401 and the body can be replayed.
+func AuthMiddleware(authenticator Authenticator) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			authenticated := req.Clone(req.Context())
+			refreshable, ok := authenticator.(RefreshableAuthenticator)
+			var generation uint64
+			var err error
+			if ok {
+				generation, err = refreshable.ApplyGeneration(authenticated)
+			} else {
+				err = authenticator.Apply(authenticated)
+			}
+			if err != nil {
+				return nil, err
+			}
+			httpResponse, err := next.RoundTrip(authenticated)
+
+			if !ok || err != nil || httpResponse.StatusCode != http.StatusUnauthorized {
+				return httpResponse, err
+			}
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				return httpResponse, nil
+			}
+
+			tflog.Debug(req.Context(), "Refreshing credentials after 401 Unauthorized", map[string]interface{}{
+				"url": redact.String(req.URL.Redacted()),
+			})
+			refreshable.Invalidate(generation)
+			retry := req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return httpResponse, nil
+				}
+				retry.Body = body
+			}
+			if err := refreshable.Apply(retry); err != nil {
+				tflog.Warn(req.Context(), "Refreshing credentials failed", map[string]interface{}{"error": redact.String(err.Error())})
+				return httpResponse, nil
+			}
+			drainBody(httpResponse)
+			return next.RoundTrip(retry)
+		})
+	}
+}
+
+// RetryMiddleware retries failed requests. See RetryTransport.
+func RetryMiddleware(config RetryConfig) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return NewRetryTransport(next, config)
+	}
+}
+
+// RateLimitMiddleware sends requests through the limiter. See RateLimitTransport.
+func RateLimitMiddleware(limiter *RateLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return NewRateLimitTransport(next, limiter)
+	}
+}
+
+// RedactionMiddleware masks secrets registered with the redact package from tflog output for the rest of the chain
+func RedactionMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return next.RoundTrip(req.WithContext(redact.Context(req.Context())))
+		})
+	}
+}
+
+// TimeoutMiddleware bounds each request, including reading its response body, by timeout
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if timeout <= 0 {
+				return next.RoundTrip(req)
+			}
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			httpResponse, err := next.RoundTrip(req.WithContext(ctx))
+			if err != nil || httpResponse.Body == nil {
+				cancel()
+				return httpResponse, err
+			}
+			httpResponse.Body = &cancelOnClose{ReadCloser: httpResponse.Body, cancel: cancel}
+			return httpResponse, nil
+		})
+	}
+}
+
+// LoggingMiddleware logs each request and response at debug level with sensitive headers masked
+// and secrets registered with the redact package removed
+func LoggingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := redact.Context(req.Context())
+			tflog.Debug(ctx, "Sending API request", map[string]interface{}{
+				"method":  req.Method,
+				"url":     redact.String(req.URL.Redacted()),
+				"headers": maskHeaders(req.Header),
+			})
+
+			start := time.Now()
+			httpResponse, err := next.RoundTrip(req)
+			fields := map[string]interface{}{
+				"method":   req.Method,
+				"url":      redact.String(req.URL.Redacted()),
+				"duration": time.Since(start).String(),
+			}
+			if err != nil {
+				fields["error"] = redact.String(err.Error())
+				tflog.Debug(ctx, "API request failed", fields)
+				return httpResponse, err
+			}
+			fields["status"] = httpResponse.StatusCode
+			fields["headers"] = maskHeaders(httpResponse.Header)
+			tflog.Debug(ctx, "Received API response", fields)
+			return httpResponse, nil
+		})
+	}
+}
+
+// maskHeaders returns the headers as a map with sensitive values replaced by redact.Mask
+func maskHeaders(header http.Header) map[string]string {
+	masked := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		for _, sensitive := range SensitiveHeaders {
+			if strings.EqualFold(name, sensitive) {
+				value = redact.Mask
+				break
+			}
+		}
+		masked[name] = redact.String(value)
+	}
+	return masked
+}
+
+// cancelOnClose cancels the request context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the request context
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
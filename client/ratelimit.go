@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+// DefaultRateLimitBackoff is how long the limiter pauses after a 429 response without a wait hint
+const DefaultRateLimitBackoff = time.Second
+
+// RateLimitConfig configures RateLimiter
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained request rate; zero disables client-side limiting,
+	// but the limiter still pauses when the server reports that it is rate limiting
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once, at least 1
+	Burst int
+	// MinRequestsPerSecond is the floor the rate backs off to after 429 responses, a tenth of RequestsPerSecond when unset
+	MinRequestsPerSecond float64
+}
+
+// RateLimiter is a token bucket shared by every request of a provider. It halves its rate on each
+// 429 response, pauses for as long as the server asks, and recovers gradually on successful responses.
+type RateLimiter struct {
+	config RateLimitConfig
+	now    func() time.Time
+
+	mu           sync.Mutex
+	rate         float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter creates a limiter with a full bucket
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.Burst < 1 {
+		config.Burst = 1
+	}
+	if config.MinRequestsPerSecond <= 0 || config.MinRequestsPerSecond > config.RequestsPerSecond {
+		config.MinRequestsPerSecond = config.RequestsPerSecond / 10
+	}
+	return &RateLimiter{
+		config: config,
+		now:    time.Now,
+		rate:   config.RequestsPerSecond,
+		tokens: float64(config.Burst),
+	}
+}
+
+// Rate returns the current request rate, which is lower than configured after 429 responses
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// Wait blocks until a request may be sent. When the wait would outlast the context deadline it returns
+// an errors.RateLimitError at once, so the caller can report how long the request would have waited.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && l.now().Add(wait).After(deadline) {
+		l.cancel()
+		return &errors.RateLimitError{Wait: wait, Err: context.DeadlineExceeded}
+	}
+
+	tflog.Debug(ctx, "Waiting for rate limiter", map[string]interface{}{"wait": wait.String()})
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return &errors.RateLimitError{Wait: wait, Err: ctx.Err()}
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Observe adapts the limiter to a response: it backs off on 429 responses and when the server reports
+// that the rate limit window is used up, and recovers on successful responses
+func (l *RateLimiter) Observe(httpResponse *http.Response) {
+	if httpResponse == nil {
+		return
+	}
+	now := l.now()
+	wait, hinted := errors.RateLimitWait(httpResponse.Header, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case httpResponse.StatusCode == http.StatusTooManyRequests:
+		if !hinted {
+			wait = DefaultRateLimitBackoff
+		}
+		l.rate = max(l.rate/2, l.config.MinRequestsPerSecond)
+		l.tokens = min(l.tokens, 0)
+	case hinted:
+	case httpResponse.StatusCode < 400:
+		l.rate = min(l.rate+l.config.RequestsPerSecond/10, l.config.RequestsPerSecond)
+		return
+	default:
+		return
+	}
+	if until := now.Add(wait); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	var wait time.Duration
+	if l.rate > 0 {
+		if !l.last.IsZero() {
+			l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, float64(l.config.Burst))
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	return max(wait, l.blockedUntil.Sub(now))
+}
+
+// cancel returns a reserved token that was not used
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate > 0 {
+		l.tokens = min(l.tokens+1, float64(l.config.Burst))
+	}
+}
+
+// RateLimitTransport is an http.RoundTripper that sends requests through a RateLimiter.
+// Place it beneath a RetryTransport so that every retry also waits for the limiter.
+type RateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+// NewRateLimitTransport wraps base (or http.DefaultTransport if nil) with the limiter
+func NewRateLimitTransport(base http.RoundTripper, limiter *RateLimiter) *RateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RateLimitTransport{
+		base:    base,
+		limiter: limiter,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	httpResponse, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.limiter.Observe(httpResponse)
+	}
+	return httpResponse, err
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func testRateLimiter(config RateLimitConfig) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(config)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	limiter, clock := testRateLimiter(RateLimitConfig{RequestsPerSecond: 2, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if wait := limiter.reserve(); wait != 0 {
+			t.Fatalf("request %d waited %s, expected the burst to be sent at once", i, wait)
+		}
+	}
+	if wait := limiter.reserve(); wait != 500*time.Millisecond {
+		t.Fatalf("fourth request waited %s, expected 500ms", wait)
+	}
+
+	clock.now = clock.now.Add(2 * time.Second)
+	if wait := limiter.reserve(); wait != 0 {
+		t.Fatalf("request after refill waited %s, expected none", wait)
+	}
+}
+
+func TestRateLimiter_AdaptsTo429(t *testing.T) {
+	limiter, clock := testRateLimiter(RateLimitConfig{RequestsPerSecond: 10, Burst: 10})
+
+	limiter.Observe(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}})
+	if limiter.Rate() != 5 {
+		t.Fatalf("Rate() = %v after a 429, expected 5", limiter.Rate())
+	}
+	if wait := limiter.reserve(); wait != 30*time.Second {
+		t.Fatalf("request after a 429 waited %s, expected the 30s Retry-After", wait)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	for i := 0; i < 10; i++ {
+		limiter.Observe(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	}
+	if limiter.Rate() != 10 {
+		t.Fatalf("Rate() = %v after successful responses, expected it to recover to 10", limiter.Rate())
+	}
+}
+
+func TestRateLimiter_HonoursRateLimitHeaders(t *testing.T) {
+	limiter, _ := testRateLimiter(RateLimitConfig{})
+
+	limiter.Observe(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{"12"},
+	}})
+	if wait := limiter.reserve(); wait != 12*time.Second {
+		t.Fatalf("request after the window was used up waited %s, expected 12s", wait)
+	}
+}
+
+func TestRateLimiter_WaitBeyondDeadline(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerSecond: 0.1, Burst: 1})
+	limiter.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx)
+
+	rateLimitErr, ok := errors.AsRateLimitError(err)
+	if !ok || rateLimitErr.Wait < 9*time.Second {
+		t.Fatalf("Wait() = %v, expected a RateLimitError with the 10s wait", err)
+	}
+
+	diags := diag.Diagnostics{}
+	errors.HandleAPIError("Error reading application", &err, nil, &diags)
+	if diags.ErrorsCount() != 1 || diags[0].Summary() != "Rate Limited" {
+		t.Fatalf("expected a Rate Limited diagnostic, got: %v", diags)
+	}
+}
+
+func TestRateLimitTransport_ObservesResponses(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusTooManyRequests, http.StatusOK)
+	limiter := NewRateLimiter(RateLimitConfig{RequestsPerSecond: 100, Burst: 1})
+	client := &http.Client{Transport: NewRetryTransport(NewRateLimitTransport(nil, limiter), testRetryConfig())}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || *calls != 2 {
+		t.Fatalf("StatusCode = %d after %d calls, expected 200 after 2", resp.StatusCode, *calls)
+	}
+	if elapsed := time.Since(start); elapsed < DefaultRateLimitBackoff {
+		t.Fatalf("retry was sent after %s, expected the limiter to pause for %s", elapsed, DefaultRateLimitBackoff)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when RetryConfig.MaxRetries is unset
+	DefaultMaxRetries = 4
+
+	// DefaultMinBackoff is the initial backoff used when RetryConfig.MinBackoff is unset
+	DefaultMinBackoff = 500 * time.Millisecond
+
+	// DefaultMaxBackoff is the backoff cap used when RetryConfig.MaxBackoff is unset
+	DefaultMaxBackoff = 30 * time.Second
+
+	// DefaultMaxRetryAfter is the longest Retry-After honoured when RetryConfig.MaxRetryAfter is unset
+	DefaultMaxRetryAfter = 5 * time.Minute
+)
+
+// RetryConfig configures RetryTransport
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries after the first attempt
+	MaxRetries int
+	// MinBackoff is the backoff before the first retry; it doubles on each retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps the wait requested by a Retry-After header
+	MaxRetryAfter time.Duration
+}
+
+// RetryTransport is an http.RoundTripper that retries failed requests with jittered exponential backoff.
+// Idempotent requests are retried on transport errors and 5xx responses; any request is retried on
+// 429 Too Many Requests. A Retry-After header on 429 and 503 responses takes precedence over the backoff.
+type RetryTransport struct {
+	base   http.RoundTripper
+	config RetryConfig
+}
+
+// NewRetryTransport wraps base (or http.DefaultTransport if nil) with retries
+func NewRetryTransport(base http.RoundTripper, config RetryConfig) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = DefaultMaxRetries
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DefaultMinBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+	return &RetryTransport{
+		base:   base,
+		config: config,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for retries := 0; ; retries++ {
+		attempt, err := rewindRequest(req, retries)
+		if err != nil {
+			return nil, err
+		}
+
+		httpResponse, err := t.base.RoundTrip(attempt)
+		if retries >= t.config.MaxRetries || !t.shouldRetry(req, httpResponse, err) {
+			return finalResult(httpResponse, err, retries)
+		}
+
+		wait := t.backoff(retries, httpResponse)
+		drainBody(httpResponse)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &errors.RetryError{Retries: retries, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry decides whether a request should be retried after the given result
+func (t *RetryTransport) shouldRetry(req *http.Request, httpResponse *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		if _, rateLimited := errors.AsRateLimitError(err); rateLimited {
+			return false
+		}
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+	switch {
+	case httpResponse.StatusCode == http.StatusTooManyRequests:
+		return true
+	case httpResponse.StatusCode == http.StatusNotImplemented:
+		return false
+	case errors.IsServerError(httpResponse.StatusCode):
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the next retry, preferring a Retry-After or rate limit reset header on 429 and 503 responses
+func (t *RetryTransport) backoff(retries int, httpResponse *http.Response) time.Duration {
+	if httpResponse != nil && (httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := errors.RateLimitWait(httpResponse.Header, time.Now()); ok {
+			return min(wait, t.config.MaxRetryAfter)
+		}
+	}
+
+	// Double step by step so large retry counts stop at MaxBackoff instead of overflowing
+	backoff := t.config.MinBackoff
+	for i := 0; i < retries && backoff < t.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, t.config.MaxBackoff)
+	// Full jitter in the upper half keeps retries from synchronising across parallel resources
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// isIdempotent reports whether the HTTP method can safely be repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewindRequest returns the request to send for the given retry, with a fresh body when one is needed
+func rewindRequest(req *http.Request, retries int) (*http.Request, error) {
+	if retries == 0 || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	attempt := req.Clone(req.Context())
+	attempt.Body = body
+	return attempt, nil
+}
+
+// finalResult records the retry count in the response's request context or wraps the error when the request was retried
+func finalResult(httpResponse *http.Response, err error, retries int) (*http.Response, error) {
+	if err != nil {
+		if retries > 0 {
+			return nil, &errors.RetryError{Retries: retries, Err: err}
+		}
+		return nil, err
+	}
+	errors.SetRetryCount(httpResponse, retries)
+	return httpResponse, nil
+}
+
+// drainBody discards and closes a response body so the connection can be reused
+func drainBody(httpResponse *http.Response) {
+	if httpResponse == nil || httpResponse.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(httpResponse.Body, 1<<16))
+	_ = httpResponse.Body.Close()
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}
+}
+
+func statusSequenceServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		status := statuses[min(int(n)-1, len(statuses)-1)]
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, http.StatusText(status))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryTransport_RetriesServerErrorForIdempotentMethod(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, expected 200", resp.StatusCode)
+	}
+	if *calls != 3 {
+		t.Fatalf("server received %d calls, expected 3", *calls)
+	}
+	if errors.RetryCount(nil, resp) != 2 {
+		t.Fatalf("RetryCount = %d, expected 2", errors.RetryCount(nil, resp))
+	}
+	if resp.Request.URL.String() != server.URL {
+		t.Fatalf("Request.URL = %s, expected the original request", resp.Request.URL)
+	}
+}
+
+func TestRetryTransport_BackoffLargeRetryCount(t *testing.T) {
+	transport := NewRetryTransport(nil, RetryConfig{MaxRetries: 1000})
+	for _, retries := range []int{35, 40, 62, 64, 100, 1000} {
+		backoff := transport.backoff(retries, nil)
+		if backoff < DefaultMaxBackoff/2 || backoff > DefaultMaxBackoff {
+			t.Fatalf("backoff(%d) = %s, expected between %s and %s", retries, backoff, DefaultMaxBackoff/2, DefaultMaxBackoff)
+		}
+	}
+}
+
+func TestRetryTransport_DoesNotRetryServerErrorForPost(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusInternalServerError, http.StatusOK)
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Post returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError || *calls != 1 {
+		t.Fatalf("StatusCode = %d after %d calls, expected 500 after 1", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransport_RetriesTooManyRequestsForPost(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusTooManyRequests, http.StatusCreated)
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+	if err != nil {
+		t.Fatalf("Post returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated || *calls != 2 {
+		t.Fatalf("StatusCode = %d after %d calls, expected 201 after 2", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransport_ReturnsLastResponseWhenExhausted(t *testing.T) {
+	server, calls := statusSequenceServer(t, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusServiceUnavailable || string(body) != "Service Unavailable" {
+		t.Fatalf("got %d %q, expected the last 503 response with its body", resp.StatusCode, body)
+	}
+	if *calls != 4 {
+		t.Fatalf("server received %d calls, expected 4", *calls)
+	}
+
+	diags := diag.Diagnostics{}
+	reqErr := stderrors.New("503 Service Unavailable")
+	errors.HandleAPIError("Error reading repository", &reqErr, resp, &diags)
+	if !strings.Contains(diags.Errors()[0].Detail(), "retried 3 time(s)") {
+		t.Fatalf("Error detail = %s, expected it to include the retry count", diags.Errors()[0].Detail())
+	}
+}
+
+func TestRetryTransport_HonoursRetryAfter(t *testing.T) {
+	var calls int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		second = time.Now()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if second.Sub(first) < 900*time.Millisecond {
+		t.Fatalf("retry happened after %s, expected Retry-After of 1s to be honoured", second.Sub(first))
+	}
+}
+
+func TestRetryTransport_ContextCancellation(t *testing.T) {
+	server, _ := statusSequenceServer(t, http.StatusServiceUnavailable)
+	config := testRetryConfig()
+	config.MinBackoff = time.Minute
+	config.MaxBackoff = time.Minute
+	client := &http.Client{Transport: NewRetryTransport(nil, config)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	_, err := client.Do(req)
+	if err == nil {
+		t.Fatal("Do should return an error when the context is cancelled")
+	}
+	if !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, expected it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryTransport_TransportErrorReportsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: NewRetryTransport(nil, testRetryConfig())}
+	_, err := client.Get(url)
+	if err == nil {
+		t.Fatal("Get should fail against a closed server")
+	}
+	if errors.RetryCount(err, nil) != 3 {
+		t.Fatalf("RetryCount = %d, expected 3", errors.RetryCount(err, nil))
+	}
+}
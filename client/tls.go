@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLSConfig configures how the client verifies the server and identifies itself
+type TLSConfig struct {
+	// CACertFile is a PEM bundle of CAs trusted in addition to the system pool
+	CACertFile string
+	// CACertPEM is PEM encoded CAs trusted in addition to the system pool
+	CACertPEM []byte
+	// ClientCertFile and ClientKeyFile are a PEM client certificate and key for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// MinVersion is the minimum TLS version, such as "1.2" or "1.3"; TLS 1.2 when unset
+	MinVersion string
+	// InsecureSkipVerify disables verification of the server certificate
+	InsecureSkipVerify bool
+}
+
+// tlsVersions maps MinVersion values to TLS versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Build creates the tls.Config described by the settings
+func (c TLSConfig) Build() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.MinVersion != "" {
+		version, ok := tlsVersions[c.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported minimum TLS version %q, expected one of 1.0, 1.1, 1.2 or 1.3", c.MinVersion)
+		}
+		config.MinVersion = version
+	}
+
+	if c.CACertFile != "" || len(c.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if c.CACertFile != "" {
+			pem, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("CA certificate file %s contains no PEM certificates", c.CACertFile)
+			}
+		}
+		if len(c.CACertPEM) > 0 && !pool.AppendCertsFromPEM(c.CACertPEM) {
+			return nil, fmt.Errorf("CA certificate contains no PEM certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+		certificate, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// ProxyConfig configures the proxy used to reach the server
+type ProxyConfig struct {
+	// URL is the proxy for both http and https requests; when unset HTTP_PROXY, HTTPS_PROXY and NO_PROXY are used
+	URL string
+	// NoProxy lists hosts, domains, IPs and CIDR ranges reached directly, in NO_PROXY syntax
+	NoProxy string
+	// Disabled sends every request directly, ignoring the environment
+	Disabled bool
+}
+
+// ProxyFunc returns the proxy selection function for http.Transport
+func (c ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if c.Disabled {
+		return nil, nil
+	}
+	if c.URL == "" {
+		config := httpproxy.FromEnvironment()
+		if c.NoProxy != "" {
+			config.NoProxy = c.NoProxy
+		}
+		return requestProxyFunc(config.ProxyFunc()), nil
+	}
+	if _, err := url.Parse(c.URL); err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	config := &httpproxy.Config{HTTPProxy: c.URL, HTTPSProxy: c.URL, NoProxy: c.NoProxy}
+	return requestProxyFunc(config.ProxyFunc()), nil
+}
+
+// requestProxyFunc adapts a URL based proxy function to http.Transport
+func requestProxyFunc(proxy func(*url.URL) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
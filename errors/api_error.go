@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// RequestIDHeaders are the response headers checked, in order, for the server's request ID
+var RequestIDHeaders = []string{"X-Sonatype-Request-Id", "X-Request-Id"}
+
+// ResponseError is the typed API error, an error returned by a Sonatype API together with the request and
+// response that caused it. It is named ResponseError because APIError remains the deprecated message helper.
+type ResponseError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+	Body       []byte
+	// BodyTruncated is true when Body holds only the start of a larger response body
+	BodyTruncated bool
+	RequestID     string
+	// RetryAfter is how long the server asked the client to wait before retrying, if it said
+	RetryAfter time.Duration
+	Err        error
+}
+
+// bodyError is implemented by errors from generated OpenAPI clients, which consume the response body
+type bodyError interface {
+	Body() []byte
+}
+
+// NewResponseError creates a ResponseError from an HTTP response and the error returned with it.
+// The response body is read and restored so the response can still be used by the caller.
+func NewResponseError(httpResponse *http.Response, err error) *ResponseError {
+	apiErr := &ResponseError{Err: err}
+	if httpResponse != nil {
+		apiErr.StatusCode = httpResponse.StatusCode
+		apiErr.Status = httpResponse.Status
+		captured := CaptureBody(httpResponse, MaxBodyCaptureSize)
+		apiErr.Body, apiErr.BodyTruncated = captured.Bytes, captured.Truncated
+		apiErr.RequestID = requestID(httpResponse)
+		if wait, ok := RateLimitWait(httpResponse.Header, time.Now()); ok {
+			apiErr.RetryAfter = wait
+		}
+		if httpResponse.Request != nil {
+			apiErr.Method = httpResponse.Request.Method
+			if httpResponse.Request.URL != nil {
+				apiErr.URL = httpResponse.Request.URL.Redacted()
+			}
+		}
+	}
+	if len(apiErr.Body) == 0 {
+		var withBody bodyError
+		if errors.As(err, &withBody) {
+			apiErr.Body = withBody.Body()
+		}
+	}
+	if apiErr.Status == "" && apiErr.StatusCode != 0 {
+		apiErr.Status = fmt.Sprintf("%d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	}
+	return apiErr
+}
+
+// AsResponseError finds the first ResponseError in the error chain
+func AsResponseError(err error) (*ResponseError, bool) {
+	var apiErr *ResponseError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// StatusCode returns the HTTP status code of the first ResponseError in the error chain, or 0 if there is none
+func StatusCode(err error) int {
+	if apiErr, ok := AsResponseError(err); ok {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// Error implements the error interface. Registered secrets are redacted from the message.
+func (e *ResponseError) Error() string {
+	message := e.Status
+	if message == "" {
+		message = "no response"
+	}
+	if e.Method != "" || e.URL != "" {
+		message = fmt.Sprintf("%s %s: %s", e.Method, e.URL, message)
+	}
+	if len(e.Body) > 0 {
+		message = fmt.Sprintf("%s: %s", message, e.Body)
+	}
+	if e.Err != nil {
+		message = fmt.Sprintf("%s: %v", message, e.Err)
+	}
+	return redact.String(message)
+}
+
+// Unwrap returns the cause of the error
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound checks if the API returned a 404
+func (e *ResponseError) IsNotFound() bool {
+	return IsNotFound(e.StatusCode)
+}
+
+// IsForbidden checks if the API returned a 403
+func (e *ResponseError) IsForbidden() bool {
+	return IsForbidden(e.StatusCode)
+}
+
+// IsUnauthorized checks if the API returned a 401
+func (e *ResponseError) IsUnauthorized() bool {
+	return IsUnauthorized(e.StatusCode)
+}
+
+// IsConflict checks if the API returned a 409
+func (e *ResponseError) IsConflict() bool {
+	return IsConflict(e.StatusCode)
+}
+
+// IsClientError checks if the API returned a 4xx error
+func (e *ResponseError) IsClientError() bool {
+	return IsClientError(e.StatusCode)
+}
+
+// IsServerError checks if the API returned a 5xx error
+func (e *ResponseError) IsServerError() bool {
+	return IsServerError(e.StatusCode)
+}
+
+// details describes the response for diagnostics, including the request ID when known
+func (e *ResponseError) details() string {
+	details := e.Status
+	if details == "" {
+		details = "Unknown error"
+	}
+	if len(e.Body) > 0 {
+		details = fmt.Sprintf("%s: %s", details, CapturedBody{Bytes: e.Body, Truncated: e.BodyTruncated})
+	}
+	return withRequestID(details, e.RequestID)
+}
+
+// requestID returns the server request ID from the response headers, or an empty string
+func requestID(httpResponse *http.Response) string {
+	if httpResponse == nil {
+		return ""
+	}
+	for _, header := range RequestIDHeaders {
+		if id := httpResponse.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// withRequestID appends the server request ID to a diagnostic detail when known
+func withRequestID(detail string, requestID string) string {
+	if requestID == "" {
+		return detail
+	}
+	return fmt.Sprintf("%s (request ID: %s)", detail, requestID)
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func testAPIResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Scheme: "https", Host: "nexus.example.com", Path: "/service/rest/v1/repositories/maven-releases", User: url.UserPassword("admin", "secret")},
+		},
+	}
+}
+
+type testOpenAPIError struct {
+	body []byte
+}
+
+func (e testOpenAPIError) Error() string { return "404 Not Found" }
+func (e testOpenAPIError) Body() []byte  { return e.body }
+
+// TestNewResponseError tests the NewResponseError function
+func TestNewResponseError(t *testing.T) {
+	cause := errors.New("unexpected status")
+	resp := testAPIResponse(http.StatusNotFound, "repository not found")
+
+	apiErr := NewResponseError(resp, cause)
+
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Method != http.MethodGet || apiErr.RequestID != "req-123" {
+		t.Fatalf("NewResponseError() = %+v, unexpected metadata", apiErr)
+	}
+	if string(apiErr.Body) != "repository not found" {
+		t.Fatalf("Body = %q, expected 'repository not found'", apiErr.Body)
+	}
+	if strings.Contains(apiErr.URL, "secret") {
+		t.Fatalf("URL %q should not contain the password", apiErr.URL)
+	}
+	if !errors.Is(apiErr, cause) {
+		t.Fatal("ResponseError should unwrap to its cause")
+	}
+
+	restored, _ := io.ReadAll(resp.Body)
+	if string(restored) != "repository not found" {
+		t.Fatalf("response body = %q, expected it to be restored", restored)
+	}
+}
+
+// TestNewResponseError_OpenAPIBody tests NewResponseError with a body kept on a generated client error
+func TestNewResponseError_OpenAPIBody(t *testing.T) {
+	resp := testAPIResponse(http.StatusBadRequest, "")
+
+	apiErr := NewResponseError(resp, testOpenAPIError{body: []byte("invalid format")})
+
+	if string(apiErr.Body) != "invalid format" {
+		t.Fatalf("Body = %q, expected the body from the client error", apiErr.Body)
+	}
+}
+
+// TestResponseError_Predicates tests the status predicates on ResponseError
+func TestResponseError_Predicates(t *testing.T) {
+	var err error = fmt.Errorf("reading repository: %w", NewResponseError(testAPIResponse(http.StatusConflict, ""), nil))
+
+	apiErr, ok := AsResponseError(err)
+	if !ok {
+		t.Fatal("AsResponseError should find a wrapped ResponseError")
+	}
+	if !apiErr.IsConflict() || !apiErr.IsClientError() || apiErr.IsNotFound() || apiErr.IsServerError() {
+		t.Fatal("predicates should reflect a 409 response")
+	}
+	if StatusCode(err) != http.StatusConflict {
+		t.Fatalf("StatusCode() = %d, expected 409", StatusCode(err))
+	}
+	if StatusCode(errors.New("plain")) != 0 {
+		t.Fatal("StatusCode() should be 0 without a ResponseError")
+	}
+}
+
+// TestAddAPIErrorDiagnostic_ResponseError tests AddAPIErrorDiagnostic with a ResponseError
+func TestAddAPIErrorDiagnostic_ResponseError(t *testing.T) {
+	diags := &diag.Diagnostics{}
+	AddAPIErrorDiagnostic(diags, "reading", "repository", nil, NewResponseError(testAPIResponse(http.StatusNotFound, "missing"), nil))
+
+	detail := diags.Errors()[0].Detail()
+	if detail != "Could not reading repository:  404 Not Found: missing (request ID: req-123)\n\nError code: SONA-E-404" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+}
+
+// TestHandleAPIError_ResponseError tests HandleAPIError with a ResponseError
+func TestHandleAPIError_ResponseError(t *testing.T) {
+	diags := diag.Diagnostics{}
+	var err error = NewResponseError(testAPIResponse(http.StatusInternalServerError, "boom"), errors.New("500 Internal Server Error"))
+
+	HandleAPIError("Error reading repository", &err, nil, &diags)
+
+	detail := diags.Errors()[0].Detail()
+	if detail != "500 Internal Server Error: 500 Internal Server Error: boom (request ID: req-123)\n\nError code: SONA-E-500" {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// DefaultMaxBodyCaptureSize is the default number of response body bytes read for diagnostics
+const DefaultMaxBodyCaptureSize = 64 * 1024
+
+// DefaultMaxBodyDisplayLength is the default number of body characters included in a diagnostic
+const DefaultMaxBodyDisplayLength = 2048
+
+// MaxBodyCaptureSize is the number of response body bytes read for diagnostics; values <= 0 use the default
+var MaxBodyCaptureSize int64 = DefaultMaxBodyCaptureSize
+
+// MaxBodyDisplayLength is the number of body characters included in a diagnostic; values <= 0 use the default
+var MaxBodyDisplayLength = DefaultMaxBodyDisplayLength
+
+var (
+	htmlTitlePattern  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlScriptPattern = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// CapturedBody is the start of a response body captured for diagnostics
+type CapturedBody struct {
+	Bytes []byte
+	// Truncated is true when the body was longer than the capture limit
+	Truncated bool
+	// Err is the error reading or closing the body, if any
+	Err error
+}
+
+// CaptureBody reads up to limit bytes of the response body and restores the body so it can be read again.
+// A body that fits within the limit is closed; a longer body is left open for the next reader.
+// A limit <= 0 uses DefaultMaxBodyCaptureSize.
+func CaptureBody(httpResponse *http.Response, limit int64) CapturedBody {
+	if httpResponse == nil || httpResponse.Body == nil || httpResponse.Body == http.NoBody {
+		return CapturedBody{}
+	}
+	if limit <= 0 {
+		limit = DefaultMaxBodyCaptureSize
+	}
+
+	original := httpResponse.Body
+	data, err := io.ReadAll(io.LimitReader(original, limit+1))
+	if err == nil && int64(len(data)) > limit {
+		httpResponse.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), original), original}
+		return CapturedBody{Bytes: data[:limit], Truncated: true}
+	}
+
+	if closeErr := original.Close(); err == nil {
+		err = closeErr
+	}
+	httpResponse.Body = io.NopCloser(bytes.NewReader(data))
+	return CapturedBody{Bytes: data, Err: err}
+}
+
+// String returns the body in a form suitable for a diagnostic: parsed field errors, the text of an
+// HTML error page, or the raw body, shortened to MaxBodyDisplayLength characters
+func (b CapturedBody) String() string {
+	if !b.Truncated {
+		if fieldErrors, ok := ParseFieldErrors(b.Bytes); ok {
+			messages := make([]string, 0, len(fieldErrors))
+			for _, fieldError := range fieldErrors {
+				messages = append(messages, fieldError.String())
+			}
+			return strings.Join(messages, "; ")
+		}
+	}
+
+	text := strings.ToValidUTF8(string(b.Bytes), "")
+	if strings.HasPrefix(http.DetectContentType(b.Bytes), "text/html") {
+		text = htmlText(text)
+	}
+
+	maxLength := MaxBodyDisplayLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxBodyDisplayLength
+	}
+	truncated := b.Truncated
+	if utf8.RuneCountInString(text) > maxLength {
+		text = string([]rune(text)[:maxLength])
+		truncated = true
+	}
+	if truncated {
+		text = fmt.Sprintf("%s... (truncated)", text)
+	}
+	return text
+}
+
+// AddWarning adds a warning when the body could not be read or closed cleanly
+func (b CapturedBody) AddWarning(diags *diag.Diagnostics) {
+	if b.Err == nil || diags == nil {
+		return
+	}
+	addWarning(
+		diags,
+		CodeResponseBody,
+		"Error Reading Response Body",
+		fmt.Sprintf("The API response body could not be read or closed cleanly, so error details may be incomplete: %v", b.Err),
+	)
+}
+
+// htmlText reduces an HTML error page to its title, or to its visible text when it has none
+func htmlText(page string) string {
+	if match := htmlTitlePattern.FindStringSubmatch(page); match != nil {
+		if title := strings.Join(strings.Fields(match[1]), " "); title != "" {
+			return title
+		}
+	}
+	page = htmlScriptPattern.ReplaceAllString(page, " ")
+	page = htmlTagPattern.ReplaceAllString(page, " ")
+	return strings.Join(strings.Fields(page), " ")
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// FieldError is a single failure reported in an API error body
+type FieldError struct {
+	// ID is the identifier reported by the server, e.g. "PARAMETER name"
+	ID string
+	// Field is the field name taken from the ID, or empty when the error is not about a field
+	Field   string
+	Message string
+}
+
+// String formats the field error for diagnostics
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// BodyParser extracts field errors from an API error body
+type BodyParser interface {
+	// Parse returns the field errors in the body, and false when the body is not in the parser's format
+	Parse(body []byte) ([]FieldError, bool)
+}
+
+// BodyParserFunc adapts a function to the BodyParser interface
+type BodyParserFunc func(body []byte) ([]FieldError, bool)
+
+// Parse calls the function
+func (f BodyParserFunc) Parse(body []byte) ([]FieldError, bool) {
+	return f(body)
+}
+
+// BodyParsers are tried in order by ParseFieldErrors. Providers may append their own parsers.
+var BodyParsers = []BodyParser{
+	BodyParserFunc(ParseNexusErrorBody),
+	BodyParserFunc(ParseIQErrorBody),
+}
+
+// FieldPaths maps API field names to Terraform attribute paths
+type FieldPaths map[string]path.Path
+
+// nexusFieldPrefixes are stripped from Nexus Repository error IDs to find the field name
+var nexusFieldPrefixes = []string{"PARAMETER ", "HelperBean."}
+
+// ParseNexusErrorBody parses the Nexus Repository validation format: [{"id":"PARAMETER name","message":"..."}]
+func ParseNexusErrorBody(body []byte) ([]FieldError, bool) {
+	var entries []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &entries); err != nil || len(entries) == 0 {
+		return nil, false
+	}
+
+	fieldErrors := make([]FieldError, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Message == "" {
+			return nil, false
+		}
+		field := entry.ID
+		for _, prefix := range nexusFieldPrefixes {
+			field = strings.TrimPrefix(field, prefix)
+		}
+		if field == "*" {
+			field = ""
+		}
+		fieldErrors = append(fieldErrors, FieldError{ID: entry.ID, Field: field, Message: entry.Message})
+	}
+	return fieldErrors, true
+}
+
+// ParseIQErrorBody parses the IQ Server error envelope: {"message":"...","errors":[{"field":"name","message":"..."}]}.
+// A body is in this format when it has an "errors" list or an "errorMessage"; other keys are ignored so fields
+// added by newer servers are accepted. Generic {"status":500,"message":"..."} error pages have neither key.
+func ParseIQErrorBody(body []byte) ([]FieldError, bool) {
+	var envelope struct {
+		Message      string `json:"message"`
+		ErrorMessage string `json:"errorMessage"`
+		Errors       *[]struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(&envelope); err != nil || decoder.More() {
+		return nil, false
+	}
+	if envelope.Errors == nil && envelope.ErrorMessage == "" {
+		return nil, false
+	}
+
+	var fieldErrors []FieldError
+	if envelope.Errors != nil {
+		for _, entry := range *envelope.Errors {
+			if entry.Message == "" {
+				return nil, false
+			}
+			fieldErrors = append(fieldErrors, FieldError{ID: entry.Field, Field: entry.Field, Message: entry.Message})
+		}
+	}
+	if len(fieldErrors) == 0 {
+		message := envelope.Message
+		if message == "" {
+			message = envelope.ErrorMessage
+		}
+		if message == "" {
+			return nil, false
+		}
+		fieldErrors = append(fieldErrors, FieldError{Message: message})
+	}
+	return fieldErrors, true
+}
+
+// ParseFieldErrors returns the field errors found by the first matching parser in BodyParsers
+func ParseFieldErrors(body []byte) ([]FieldError, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	for _, parser := range BodyParsers {
+		if fieldErrors, ok := parser.Parse(body); ok {
+			return fieldErrors, true
+		}
+	}
+	return nil, false
+}
+
+// AddFieldErrorDiagnostics adds a diagnostic for every field error in the body, scoped to the mapped attribute when known.
+// It returns false when the body contains no field errors so the caller can fall back to a general diagnostic.
+func AddFieldErrorDiagnostics(diags *diag.Diagnostics, summary string, body []byte, fields FieldPaths) bool {
+	fieldErrors, ok := ParseFieldErrors(body)
+	if !ok {
+		return false
+	}
+
+	for _, fieldError := range fieldErrors {
+		if attributePath, mapped := fields[fieldError.Field]; mapped && fieldError.Field != "" {
+			addAttributeError(diags, attributePath, CodeClientError, summary, fieldError.Message)
+			continue
+		}
+		addError(diags, CodeClientError, summary, fieldError.String())
+	}
+	return true
+}
+
+// HandleAPIErrorWithFields reports field errors in the body of a 400 or 422 response on their attributes.
+// Other failures, and bodies without field errors, are handled by HandleAPIError.
+func HandleAPIErrorWithFields(message string, err *error, httpResponse *http.Response, fields FieldPaths, respDiags *diag.Diagnostics) {
+	statusCode := StatusCode(*err)
+	if httpResponse != nil {
+		statusCode = httpResponse.StatusCode
+	}
+	if statusCode != http.StatusBadRequest && statusCode != http.StatusUnprocessableEntity {
+		HandleAPIError(message, err, httpResponse, respDiags)
+		return
+	}
+
+	var captured CapturedBody
+	if apiErr, ok := AsResponseError(*err); ok {
+		captured = CapturedBody{Bytes: apiErr.Body, Truncated: apiErr.BodyTruncated}
+	} else if httpResponse != nil {
+		captured = CaptureBody(httpResponse, MaxBodyCaptureSize)
+		captured.AddWarning(respDiags)
+	}
+
+	if captured.Truncated || !AddFieldErrorDiagnostics(respDiags, message, captured.Bytes, fields) {
+		HandleAPIError(message, err, httpResponse, respDiags)
+	}
+}
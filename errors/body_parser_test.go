@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// TestParseNexusErrorBody tests the ParseNexusErrorBody function
+func TestParseNexusErrorBody(t *testing.T) {
+	fieldErrors, ok := ParseNexusErrorBody([]byte(`[{"id":"PARAMETER name","message":"must not be blank"},{"id":"HelperBean.storage.blobStoreName","message":"Blob store does not exist"},{"id":"*","message":"Repository is read-only"}]`))
+	if !ok || len(fieldErrors) != 3 {
+		t.Fatalf("ParseNexusErrorBody() = %v, %v, expected three errors", fieldErrors, ok)
+	}
+
+	expected := []string{"name", "storage.blobStoreName", ""}
+	for i, field := range expected {
+		if fieldErrors[i].Field != field {
+			t.Fatalf("Field = %q, expected %q", fieldErrors[i].Field, field)
+		}
+	}
+
+	if _, ok := ParseNexusErrorBody([]byte("<html>Bad Request</html>")); ok {
+		t.Fatal("ParseNexusErrorBody should reject non JSON bodies")
+	}
+	if _, ok := ParseNexusErrorBody([]byte(`[{"name":"x"}]`)); ok {
+		t.Fatal("ParseNexusErrorBody should reject arrays without messages")
+	}
+}
+
+// TestParseIQErrorBody tests the ParseIQErrorBody function
+func TestParseIQErrorBody(t *testing.T) {
+	fieldErrors, ok := ParseIQErrorBody([]byte(`{"message":"Validation failed","errors":[{"field":"publicId","message":"already exists"}]}`))
+	if !ok || len(fieldErrors) != 1 || fieldErrors[0].Field != "publicId" {
+		t.Fatalf("ParseIQErrorBody() = %v, %v, expected a publicId error", fieldErrors, ok)
+	}
+
+	fieldErrors, ok = ParseIQErrorBody([]byte(`{"message":"Validation failed","traceId":"abc-123","errors":[{"field":"publicId","message":"already exists","code":"DUPLICATE"}]}`))
+	if !ok || len(fieldErrors) != 1 || fieldErrors[0].Field != "publicId" {
+		t.Fatalf("ParseIQErrorBody() = %v, %v, expected unknown keys to be ignored", fieldErrors, ok)
+	}
+
+	fieldErrors, ok = ParseIQErrorBody([]byte(`{"errorMessage":"Organization not found"}`))
+	if !ok || fieldErrors[0].String() != "Organization not found" {
+		t.Fatalf("ParseIQErrorBody() = %v, %v, expected the envelope message", fieldErrors, ok)
+	}
+
+	if _, ok := ParseIQErrorBody([]byte(`{"id":"x"}`)); ok {
+		t.Fatal("ParseIQErrorBody should reject objects without a message")
+	}
+	if _, ok := ParseIQErrorBody([]byte(`{"timestamp":"2024-01-01T00:00:00Z","status":500,"error":"Internal Server Error","message":"boom"}`)); ok {
+		t.Fatal("ParseIQErrorBody should reject generic error objects that have a message")
+	}
+	if _, ok := ParseIQErrorBody([]byte(`{"errors":[{"field":"name"}]}`)); ok {
+		t.Fatal("ParseIQErrorBody should reject field errors without a message")
+	}
+}
+
+// TestAddFieldErrorDiagnostics tests the AddFieldErrorDiagnostics function
+func TestAddFieldErrorDiagnostics(t *testing.T) {
+	diags := diag.Diagnostics{}
+	fields := FieldPaths{"storage.blobStoreName": path.Root("storage").AtName("blob_store_name")}
+
+	ok := AddFieldErrorDiagnostics(&diags, "Error creating repository", []byte(`[{"id":"HelperBean.storage.blobStoreName","message":"Blob store does not exist"},{"id":"PARAMETER format","message":"unsupported"}]`), fields)
+
+	if !ok || diags.ErrorsCount() != 2 {
+		t.Fatalf("expected two errors, got: %v", diags)
+	}
+	withPath, isAttribute := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !isAttribute || !withPath.Path().Equal(path.Root("storage").AtName("blob_store_name")) {
+		t.Fatalf("first error should be on storage.blob_store_name, got: %v", diags.Errors()[0])
+	}
+	if diags.Errors()[1].Detail() != "format: unsupported\n\nError code: SONA-E-400" {
+		t.Fatalf("unmapped field detail = %q, expected 'format: unsupported' with its code", diags.Errors()[1].Detail())
+	}
+
+	if AddFieldErrorDiagnostics(&diags, "Error", []byte("plain text"), fields) {
+		t.Fatal("AddFieldErrorDiagnostics should return false for unparseable bodies")
+	}
+}
+
+// TestBodyParsers_Custom tests that providers can add their own parser
+func TestBodyParsers_Custom(t *testing.T) {
+	original := BodyParsers
+	defer func() { BodyParsers = original }()
+
+	BodyParsers = append([]BodyParser{BodyParserFunc(func(body []byte) ([]FieldError, bool) {
+		field, message, ok := strings.Cut(string(body), "=")
+		return []FieldError{{ID: field, Field: field, Message: message}}, ok
+	})}, BodyParsers...)
+
+	fieldErrors, ok := ParseFieldErrors([]byte("name=too long"))
+	if !ok || fieldErrors[0].Field != "name" {
+		t.Fatalf("ParseFieldErrors() = %v, %v, expected the custom parser result", fieldErrors, ok)
+	}
+}
+
+// TestHandleAPIErrorWithFields tests the HandleAPIErrorWithFields function
+func TestHandleAPIErrorWithFields(t *testing.T) {
+	diags := diag.Diagnostics{}
+	err := errors.New("400 Bad Request")
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Status:     "400 Bad Request",
+		Body:       io.NopCloser(strings.NewReader(`[{"id":"PARAMETER name","message":"must not be blank"}]`)),
+	}
+
+	HandleAPIErrorWithFields("Error creating role", &err, resp, FieldPaths{"name": path.Root("name")}, &diags)
+
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Fatalf("expected an attribute error on name, got: %v", diags)
+	}
+
+	diags = diag.Diagnostics{}
+	resp.Body = io.NopCloser(strings.NewReader("Internal error"))
+	HandleAPIErrorWithFields("Error creating role", &err, resp, nil, &diags)
+	if diags.Errors()[0].Detail() != "400 Bad Request: 400 Bad Request: Internal error\n\nError code: SONA-E-400" {
+		t.Fatalf("unexpected fallback detail: %q", diags.Errors()[0].Detail())
+	}
+}
+
+// TestHandleAPIErrorWithFields_OtherStatuses tests that JSON bodies of non-validation failures keep their status specific diagnostics
+func TestHandleAPIErrorWithFields_OtherStatuses(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		header     http.Header
+		summary    string
+		code       Code
+		contains   []string
+	}{
+		"500": {
+			statusCode: http.StatusInternalServerError,
+			header:     http.Header{"X-Request-Id": []string{"req-500"}},
+			summary:    "Error creating role",
+			code:       CodeServerError,
+			contains:   []string{"500 Internal Server Error", "Internal error", "req-500"},
+		},
+		"503": {
+			statusCode: http.StatusServiceUnavailable,
+			header:     http.Header{},
+			summary:    "Error creating role",
+			code:       CodeServerError,
+			contains:   []string{"503 Service Unavailable", "Internal error"},
+		},
+		"429": {
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{"Retry-After": []string{"30"}},
+			summary:    "Rate Limited",
+			code:       CodeRateLimited,
+			contains:   []string{"HTTP 429", "Retry in 30s"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			err := errors.New(http.StatusText(tt.statusCode))
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Status:     fmt.Sprintf("%d %s", tt.statusCode, http.StatusText(tt.statusCode)),
+				Header:     tt.header,
+				Body:       io.NopCloser(strings.NewReader(`{"message":"Internal error"}`)),
+			}
+
+			HandleAPIErrorWithFields("Error creating role", &err, resp, FieldPaths{"name": path.Root("name")}, &diags)
+
+			if len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != tt.summary {
+				t.Fatalf("diagnostics = %v, expected a single %q error", diags, tt.summary)
+			}
+			detail := diags.Errors()[0].Detail()
+			for _, expected := range append(tt.contains, "Error code: "+string(tt.code)) {
+				if !strings.Contains(detail, expected) {
+					t.Fatalf("detail = %q, expected it to contain %q", detail, expected)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleAPIErrorWithFields_ResponseError tests that a 422 ResponseError without a response is parsed into field errors
+func TestHandleAPIErrorWithFields_ResponseError(t *testing.T) {
+	diags := diag.Diagnostics{}
+	err := error(&ResponseError{StatusCode: http.StatusUnprocessableEntity, Body: []byte(`{"errors":[{"field":"publicId","message":"already exists"}]}`)})
+
+	HandleAPIErrorWithFields("Error creating application", &err, nil, FieldPaths{"publicId": path.Root("public_id")}, &diags)
+
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("public_id")) {
+		t.Fatalf("expected an attribute error on public_id, got: %v", diags)
+	}
+}
+
+// TestParseAPIError_FieldErrors tests that ParseAPIError formats parsed bodies
+func TestParseAPIError_FieldErrors(t *testing.T) {
+	resp := &http.Response{
+		Status: "400 Bad Request",
+		Body:   io.NopCloser(strings.NewReader(`[{"id":"PARAMETER name","message":"must not be blank"},{"id":"*","message":"try again"}]`)),
+	}
+
+	if result := ParseAPIError(resp); result != "400 Bad Request: name: must not be blank; try again" {
+		t.Fatalf("ParseAPIError() = %q", result)
+	}
+}
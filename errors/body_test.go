@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type failingCloseBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *failingCloseBody) Close() error {
+	b.closed = true
+	return errors.New("connection reset")
+}
+
+// TestCaptureBody tests that a small body is captured, closed and restored
+func TestCaptureBody(t *testing.T) {
+	body := &failingCloseBody{Reader: strings.NewReader("repository not found")}
+	resp := &http.Response{Body: body}
+
+	captured := CaptureBody(resp, 1024)
+
+	if string(captured.Bytes) != "repository not found" || captured.Truncated {
+		t.Fatalf("CaptureBody() = %+v, expected the full body", captured)
+	}
+	if !body.closed || captured.Err == nil {
+		t.Fatal("CaptureBody should close the original body and record the close error")
+	}
+
+	restored, _ := io.ReadAll(resp.Body)
+	if string(restored) != "repository not found" {
+		t.Fatalf("restored body = %q, expected it to be readable again", restored)
+	}
+}
+
+// TestCaptureBody_Truncated tests that a large body is truncated but stays fully readable
+func TestCaptureBody_Truncated(t *testing.T) {
+	full := strings.Repeat("x", 100)
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(full))}
+
+	captured := CaptureBody(resp, 10)
+
+	if len(captured.Bytes) != 10 || !captured.Truncated {
+		t.Fatalf("CaptureBody() = %d bytes, truncated %v, expected 10 truncated bytes", len(captured.Bytes), captured.Truncated)
+	}
+	if captured.String() != "xxxxxxxxxx... (truncated)" {
+		t.Fatalf("String() = %q", captured.String())
+	}
+
+	restored, _ := io.ReadAll(resp.Body)
+	if string(restored) != full {
+		t.Fatalf("restored body has %d bytes, expected %d", len(restored), len(full))
+	}
+}
+
+// TestCaptureBody_NegativeLimit tests that a limit <= 0 falls back to the default capture size
+func TestCaptureBody_NegativeLimit(t *testing.T) {
+	original := MaxBodyCaptureSize
+	defer func() { MaxBodyCaptureSize = original }()
+	MaxBodyCaptureSize = -1
+
+	full := strings.Repeat("x", DefaultMaxBodyCaptureSize+10)
+	captured := CaptureBody(&http.Response{Body: io.NopCloser(strings.NewReader(full))}, MaxBodyCaptureSize)
+	if len(captured.Bytes) != DefaultMaxBodyCaptureSize || !captured.Truncated {
+		t.Fatalf("CaptureBody() = %d bytes, truncated %v, expected the default limit", len(captured.Bytes), captured.Truncated)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader("invalid repository name"))}
+	if message := ParseAPIError(resp); !strings.Contains(message, "invalid repository name") {
+		t.Fatalf("ParseAPIError() = %q, expected the body", message)
+	}
+}
+
+// TestCapturedBody_HTML tests that HTML error pages are reduced to their title or text
+func TestCapturedBody_HTML(t *testing.T) {
+	page := "<!DOCTYPE html><html><head><title>\n  502 Bad Gateway\n</title><style>body{}</style></head><body>" + strings.Repeat("<p>padding</p>", 1000) + "</body></html>"
+	if result := (CapturedBody{Bytes: []byte(page)}).String(); result != "502 Bad Gateway" {
+		t.Fatalf("String() = %q, expected the page title", result)
+	}
+
+	page = "<html><body><h1>Service Unavailable</h1><script>var x = 1;</script><p>Try again later</p></body></html>"
+	if result := (CapturedBody{Bytes: []byte(page)}).String(); result != "Service Unavailable Try again later" {
+		t.Fatalf("String() = %q, expected the visible text", result)
+	}
+}
+
+// TestCapturedBody_DisplayLength tests that long bodies are shortened for display
+func TestCapturedBody_DisplayLength(t *testing.T) {
+	original := MaxBodyDisplayLength
+	defer func() { MaxBodyDisplayLength = original }()
+	MaxBodyDisplayLength = 5
+
+	if result := (CapturedBody{Bytes: []byte("ééééééé")}).String(); result != "ééééé... (truncated)" {
+		t.Fatalf("String() = %q, expected the first five characters", result)
+	}
+}
+
+// TestCapturedBody_NegativeDisplayLength tests that a display length <= 0 falls back to the default
+func TestCapturedBody_NegativeDisplayLength(t *testing.T) {
+	original := MaxBodyDisplayLength
+	defer func() { MaxBodyDisplayLength = original }()
+	MaxBodyDisplayLength = -1
+
+	expected := strings.Repeat("x", DefaultMaxBodyDisplayLength) + "... (truncated)"
+	if result := (CapturedBody{Bytes: []byte(strings.Repeat("x", DefaultMaxBodyDisplayLength+10))}).String(); result != expected {
+		t.Fatalf("String() = %d characters, expected the default display length", len(result))
+	}
+}
+
+// TestHandleAPIError_CloseErrorIsWarning tests that a failing Body.Close is reported as a warning
+func TestHandleAPIError_CloseErrorIsWarning(t *testing.T) {
+	diags := diag.Diagnostics{}
+	err := errors.New("500 Internal Server Error")
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Status:     "500 Internal Server Error",
+		Body:       &failingCloseBody{Reader: strings.NewReader("boom")},
+	}
+
+	HandleAPIError("Error reading repository", &err, resp, &diags)
+
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one error and one warning, got: %v", diags)
+	}
+	if diags.Errors()[0].Detail() != "500 Internal Server Error: 500 Internal Server Error: boom\n\nError code: SONA-E-500" {
+		t.Fatalf("unexpected detail: %q", diags.Errors()[0].Detail())
+	}
+}
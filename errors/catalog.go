@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Code is a stable identifier for a kind of diagnostic that users can search for
+type Code string
+
+const (
+	// CodeAPIError is an API failure that fits no more specific code
+	CodeAPIError Code = "SONA-E-001"
+	// CodeNetworkError is a failure to reach the API
+	CodeNetworkError Code = "SONA-E-002"
+	// CodeTimeout is an operation that did not finish within its timeout
+	CodeTimeout Code = "SONA-E-003"
+	// CodeValidation is an invalid configuration value
+	CodeValidation Code = "SONA-E-004"
+	// CodeUnsupportedByServer is a configuration the server's version or edition cannot honour
+	CodeUnsupportedByServer Code = "SONA-E-005"
+	// CodeClientError is a 4xx response that fits no more specific code
+	CodeClientError Code = "SONA-E-400"
+	// CodeUnauthorized is a 401 response
+	CodeUnauthorized Code = "SONA-E-401"
+	// CodeForbidden is a 403 response
+	CodeForbidden Code = "SONA-E-403"
+	// CodeNotFound is a 404 response or a missing object
+	CodeNotFound Code = "SONA-E-404"
+	// CodeConflict is a 409 response
+	CodeConflict Code = "SONA-E-409"
+	// CodeRateLimited is a 429 response
+	CodeRateLimited Code = "SONA-E-429"
+	// CodeServerError is a 5xx response
+	CodeServerError Code = "SONA-E-500"
+	// CodeAPIWarning is a non-fatal API problem
+	CodeAPIWarning Code = "SONA-W-001"
+	// CodeResponseBody is a response body that could not be read or closed cleanly
+	CodeResponseBody Code = "SONA-W-002"
+)
+
+// CatalogEntry describes how diagnostics with a code are presented
+type CatalogEntry struct {
+	Code Code
+	// DocURL links to troubleshooting documentation for the code
+	DocURL string
+	// Rewrite, when set, replaces the wording of every diagnostic with the code
+	Rewrite func(summary string, detail string) (string, string)
+}
+
+// Catalog maps codes to their entries
+type Catalog struct {
+	mu      sync.RWMutex
+	entries map[Code]CatalogEntry
+	// docsBaseURL is used to build a link for codes without a DocURL
+	docsBaseURL string
+}
+
+// NewCatalog creates a catalog holding the built in codes without documentation links
+func NewCatalog() *Catalog {
+	catalog := &Catalog{entries: map[Code]CatalogEntry{}}
+	for _, code := range []Code{
+		CodeAPIError, CodeNetworkError, CodeTimeout, CodeValidation, CodeUnsupportedByServer,
+		CodeClientError, CodeUnauthorized, CodeForbidden, CodeNotFound, CodeConflict, CodeRateLimited, CodeServerError,
+		CodeAPIWarning, CodeResponseBody,
+	} {
+		catalog.entries[code] = CatalogEntry{Code: code}
+	}
+	return catalog
+}
+
+// DefaultCatalog is the catalog used by every helper in this package
+var DefaultCatalog = NewCatalog()
+
+// Register adds entries to the catalog, replacing any existing entry with the same code
+func (c *Catalog) Register(entries ...CatalogEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range entries {
+		c.entries[entry.Code] = entry
+	}
+}
+
+// Lookup returns the entry for a code
+func (c *Catalog) Lookup(code Code) (CatalogEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[code]
+	return entry, ok
+}
+
+// DocURL returns the documentation link for a code, or an empty string when there is none
+func (c *Catalog) DocURL(code Code) string {
+	entry, _ := c.Lookup(code)
+	if entry.DocURL != "" {
+		return entry.DocURL
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.docsBaseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s#%s", c.docsBaseURL, strings.ToLower(string(code)))
+}
+
+// SetDocsBaseURL links codes without a DocURL to baseURL#<code in lower case>
+func (c *Catalog) SetDocsBaseURL(baseURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.docsBaseURL = baseURL
+}
+
+// Format applies the entry's wording and appends the code, and the documentation link when known, to the detail
+func (c *Catalog) Format(code Code, summary string, detail string) (string, string) {
+	if code == "" {
+		return summary, detail
+	}
+	if entry, ok := c.Lookup(code); ok && entry.Rewrite != nil {
+		summary, detail = entry.Rewrite(summary, detail)
+	}
+	reference := fmt.Sprintf("Error code: %s", code)
+	if docURL := c.DocURL(code); docURL != "" {
+		reference = fmt.Sprintf("%s (see %s)", reference, docURL)
+	}
+	if detail == "" {
+		return summary, reference
+	}
+	return summary, fmt.Sprintf("%s\n\n%s", detail, reference)
+}
+
+// RegisterCodes adds entries to the default catalog
+func RegisterCodes(entries ...CatalogEntry) {
+	DefaultCatalog.Register(entries...)
+}
+
+// AddErrorWithCode adds an error diagnostic formatted by the default catalog, with registered secrets redacted
+func AddErrorWithCode(diags *diag.Diagnostics, code Code, summary string, detail string) {
+	addError(diags, code, summary, detail)
+}
+
+// AddWarningWithCode adds a warning diagnostic formatted by the default catalog, with registered secrets redacted
+func AddWarningWithCode(diags *diag.Diagnostics, code Code, summary string, detail string) {
+	addWarning(diags, code, summary, detail)
+}
+
+// AddAttributeErrorWithCode adds an attribute error diagnostic formatted by the default catalog, with registered secrets redacted
+func AddAttributeErrorWithCode(diags *diag.Diagnostics, attributePath path.Path, code Code, summary string, detail string) {
+	addAttributeError(diags, attributePath, code, summary, detail)
+}
+
+// CodeForStatus returns the code for an HTTP status, or CodeAPIError when the status is not an error
+func CodeForStatus(statusCode int) Code {
+	switch {
+	case IsUnauthorized(statusCode):
+		return CodeUnauthorized
+	case IsForbidden(statusCode):
+		return CodeForbidden
+	case IsNotFound(statusCode):
+		return CodeNotFound
+	case IsConflict(statusCode):
+		return CodeConflict
+	case statusCode == http.StatusTooManyRequests:
+		return CodeRateLimited
+	case IsClientError(statusCode):
+		return CodeClientError
+	case IsServerError(statusCode):
+		return CodeServerError
+	default:
+		return CodeAPIError
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// TestCatalog_Format tests that codes and documentation links are appended to the detail
+func TestCatalog_Format(t *testing.T) {
+	catalog := NewCatalog()
+
+	_, detail := catalog.Format(CodeNotFound, "Repository Not Found", "missing")
+	if detail != "missing\n\nError code: SONA-E-404" {
+		t.Fatalf("Format() detail = %q, expected the code to be appended", detail)
+	}
+
+	catalog.SetDocsBaseURL("https://docs.example.com/errors")
+	_, detail = catalog.Format(CodeNotFound, "Repository Not Found", "")
+	if detail != "Error code: SONA-E-404 (see https://docs.example.com/errors#sona-e-404)" {
+		t.Fatalf("Format() detail = %q, expected a link built from the base URL", detail)
+	}
+
+	catalog.Register(CatalogEntry{Code: CodeNotFound, DocURL: "https://docs.example.com/not-found"})
+	if docURL := catalog.DocURL(CodeNotFound); docURL != "https://docs.example.com/not-found" {
+		t.Fatalf("DocURL() = %q, expected the entry link to win over the base URL", docURL)
+	}
+
+	summary, detail := catalog.Format("", "Summary", "Detail")
+	if summary != "Summary" || detail != "Detail" {
+		t.Fatal("Format() should leave diagnostics without a code unchanged")
+	}
+}
+
+// TestCatalog_Rewrite tests that providers can override wording for a code in one place
+func TestCatalog_Rewrite(t *testing.T) {
+	t.Cleanup(func() { DefaultCatalog = NewCatalog() })
+	RegisterCodes(
+		CatalogEntry{
+			Code: CodeUnauthorized,
+			Rewrite: func(summary string, detail string) (string, string) {
+				return "Nexus Repository rejected the credentials", strings.Replace(detail, "permission", "a Nexus privilege", 1)
+			},
+		},
+		CatalogEntry{Code: "NXRM-E-100", DocURL: "https://docs.example.com/nxrm-e-100"},
+	)
+
+	diags := diag.Diagnostics{}
+	AddUnauthorizedDiagnostic(&diags, "create repositories")
+	if diags[0].Summary() != "Nexus Repository rejected the credentials" || !strings.Contains(diags[0].Detail(), "a Nexus privilege") {
+		t.Fatalf("expected the registered wording, got: %v", diags)
+	}
+
+	diags = diag.Diagnostics{}
+	AddAttributeErrorWithCode(&diags, path.Root("format"), "NXRM-E-100", "Unsupported Format", "format is not supported")
+	if !strings.HasSuffix(diags[0].Detail(), "Error code: NXRM-E-100 (see https://docs.example.com/nxrm-e-100)") {
+		t.Fatalf("expected the provider code and link, got: %q", diags[0].Detail())
+	}
+}
+
+// TestHelpersCarryCodes tests that each helper adds its stable code
+func TestHelpersCarryCodes(t *testing.T) {
+	tests := map[Code]func(diags *diag.Diagnostics){
+		CodeNotFound:    func(diags *diag.Diagnostics) { AddNotFoundDiagnostic(diags, "repository", "maven-releases") },
+		CodeValidation:  func(diags *diag.Diagnostics) { AddValidationDiagnostic(diags, "name", "too long") },
+		CodeConflict:    func(diags *diag.Diagnostics) { AddConflictDiagnostic(diags, "repository", "exists") },
+		CodeForbidden:   func(diags *diag.Diagnostics) { AddForbiddenDiagnostic(diags, "read repositories") },
+		CodeTimeout:     func(diags *diag.Diagnostics) { AddTimeoutDiagnostic(diags, "creating", "repository") },
+		CodeServerError: func(diags *diag.Diagnostics) { AddServerErrorDiagnostic(diags, "boom", 502) },
+		CodeClientError: func(diags *diag.Diagnostics) { AddClientErrorDiagnostic(diags, "bad", 405) },
+		CodeRateLimited: func(diags *diag.Diagnostics) {
+			DiagnoseResponse(diags, "reading", "repository", "", testAPIResponse(http.StatusTooManyRequests, ""), nil)
+		},
+		CodeUnauthorized: func(diags *diag.Diagnostics) {
+			AddAPIErrorDiagnostic(diags, "reading", "repository", testAPIResponse(http.StatusUnauthorized, ""), nil)
+		},
+		CodeAPIWarning: func(diags *diag.Diagnostics) {
+			var err error = http.ErrBodyNotAllowed
+			HandleAPIWarning("Problem", &err, nil, diags)
+		},
+	}
+
+	for code, add := range tests {
+		t.Run(string(code), func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			add(&diags)
+			if len(diags) != 1 || !strings.HasSuffix(diags[0].Detail(), "Error code: "+string(code)) {
+				t.Fatalf("expected a single diagnostic with code %s, got: %v", code, diags)
+			}
+		})
+	}
+}
+
+// TestCodeForStatus tests the mapping of HTTP statuses to codes
+func TestCodeForStatus(t *testing.T) {
+	tests := map[int]Code{
+		http.StatusOK:                  CodeAPIError,
+		http.StatusBadRequest:          CodeClientError,
+		http.StatusNotFound:            CodeNotFound,
+		http.StatusTooManyRequests:     CodeRateLimited,
+		http.StatusServiceUnavailable:  CodeServerError,
+		http.StatusUnprocessableEntity: CodeClientError,
+	}
+	for statusCode, expected := range tests {
+		if actual := CodeForStatus(statusCode); actual != expected {
+			t.Errorf("CodeForStatus(%d) = %s, expected %s", statusCode, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// StatusOverride remaps the status code of a response before it is diagnosed.
+// It returns the new status code and true when it applies.
+type StatusOverride func(statusCode int, body []byte) (int, bool)
+
+// NotFoundWhenBodyContains treats a response with the given status whose body contains text as a 404
+func NotFoundWhenBodyContains(statusCode int, text string) StatusOverride {
+	return func(actual int, body []byte) (int, bool) {
+		if actual == statusCode && bytes.Contains(bytes.ToLower(body), bytes.ToLower([]byte(text))) {
+			return http.StatusNotFound, true
+		}
+		return actual, false
+	}
+}
+
+// ResponseDiagnoser maps API responses to diagnostics, with per-resource overrides
+type ResponseDiagnoser struct {
+	// Overrides are applied in order and the first that applies wins
+	Overrides []StatusOverride
+	// Fields maps API field names to attributes for 400 and 422 validation errors
+	Fields FieldPaths
+}
+
+// DiagnoseResponse adds the diagnostic matching the response status, or a network diagnostic when there is no response.
+// The operation is a verb such as "reading". It returns the status code used, 0 when there was no response.
+func DiagnoseResponse(diags *diag.Diagnostics, operation string, resourceType string, resourceID string, httpResponse *http.Response, err error) int {
+	return ResponseDiagnoser{}.Diagnose(diags, operation, resourceType, resourceID, httpResponse, err)
+}
+
+// EffectiveStatus returns the status code of the response or ResponseError after applying the overrides
+func (d ResponseDiagnoser) EffectiveStatus(httpResponse *http.Response, err error) int {
+	statusCode, captured := d.response(httpResponse, err, nil)
+	return d.override(statusCode, captured.Bytes)
+}
+
+// Diagnose adds the diagnostic matching the response status. See DiagnoseResponse.
+func (d ResponseDiagnoser) Diagnose(diags *diag.Diagnostics, operation string, resourceType string, resourceID string, httpResponse *http.Response, err error) int {
+	statusCode, captured := d.response(httpResponse, err, diags)
+	statusCode = d.override(statusCode, captured.Bytes)
+
+	if statusCode == 0 || (statusCode < 400 && err != nil) {
+		if err != nil {
+			title, _ := APIErrorMessage(operation, resourceType, "")
+			HandleAPIError(title, &err, httpResponse, diags)
+		}
+		return statusCode
+	}
+	if statusCode < 400 {
+		return statusCode
+	}
+
+	details := withRetryCount(responseDetails(statusCode, captured, requestIDOf(httpResponse, err)), err, httpResponse)
+	switch {
+	case IsNotFound(statusCode):
+		AddNotFoundDiagnostic(diags, resourceType, resourceID)
+	case IsUnauthorized(statusCode):
+		addError(
+			diags,
+			CodeUnauthorized,
+			"Authentication Failed",
+			fmt.Sprintf("The server rejected the provider credentials while %s %s (HTTP 401). Check the configured username and password or user token. Details: %s", operation, resourceType, details),
+		)
+	case IsForbidden(statusCode):
+		addError(
+			diags,
+			CodeForbidden,
+			fmt.Sprintf("Forbidden %s %s", operation, resourceType),
+			fmt.Sprintf("The provider credentials are valid but not permitted to perform %s %s (HTTP 403). Grant the user the required privileges. Details: %s", operation, resourceType, details),
+		)
+	case IsConflict(statusCode):
+		addError(
+			diags,
+			CodeConflict,
+			fmt.Sprintf("Conflict %s %s", operation, resourceType),
+			fmt.Sprintf("A conflict occurred: %s", details),
+		)
+	case statusCode == http.StatusTooManyRequests:
+		AddRateLimitedDiagnostic(diags, operation, resourceType, rateLimitWaitOf(httpResponse, err), details)
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		title, _ := APIErrorMessage(operation, resourceType, "")
+		if captured.Truncated || !AddFieldErrorDiagnostics(diags, title, captured.Bytes, d.Fields) {
+			AddClientErrorDiagnostic(diags, details, statusCode)
+		}
+	case IsServerError(statusCode):
+		AddServerErrorDiagnostic(diags, details, statusCode)
+	default:
+		AddClientErrorDiagnostic(diags, details, statusCode)
+	}
+	return statusCode
+}
+
+// response returns the status code and body of the response, or of the ResponseError when there is no response
+func (d ResponseDiagnoser) response(httpResponse *http.Response, err error, diags *diag.Diagnostics) (int, CapturedBody) {
+	if httpResponse != nil {
+		captured := CaptureBody(httpResponse, MaxBodyCaptureSize)
+		captured.AddWarning(diags)
+		return httpResponse.StatusCode, captured
+	}
+	if apiErr, ok := AsResponseError(err); ok {
+		return apiErr.StatusCode, CapturedBody{Bytes: apiErr.Body, Truncated: apiErr.BodyTruncated}
+	}
+	return 0, CapturedBody{}
+}
+
+// override applies the first matching status override
+func (d ResponseDiagnoser) override(statusCode int, body []byte) int {
+	for _, override := range d.Overrides {
+		if overridden, ok := override(statusCode, body); ok {
+			return overridden
+		}
+	}
+	return statusCode
+}
+
+// requestIDOf returns the request ID from the response or the ResponseError
+func requestIDOf(httpResponse *http.Response, err error) string {
+	if id := requestID(httpResponse); id != "" {
+		return id
+	}
+	if apiErr, ok := AsResponseError(err); ok {
+		return apiErr.RequestID
+	}
+	return ""
+}
+
+// responseDetails describes the status and body for a diagnostic
+func responseDetails(statusCode int, captured CapturedBody, requestID string) string {
+	details := fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))
+	if body := captured.String(); body != "" {
+		details = fmt.Sprintf("%s: %s", details, body)
+	}
+	return withRequestID(details, requestID)
+}
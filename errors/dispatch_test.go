@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// TestDiagnoseResponse tests the DiagnoseResponse function for each status class
+func TestDiagnoseResponse(t *testing.T) {
+	tests := map[int]string{
+		http.StatusUnauthorized:        "Authentication Failed",
+		http.StatusForbidden:           "Forbidden reading repository",
+		http.StatusNotFound:            "repository Not Found",
+		http.StatusConflict:            "Conflict reading repository",
+		http.StatusUnprocessableEntity: "Client Error (422)",
+		http.StatusTooManyRequests:     "Rate Limited",
+		http.StatusMethodNotAllowed:    "Client Error (405)",
+		http.StatusInternalServerError: "Server Error (500)",
+		http.StatusBadGateway:          "Server Error (502)",
+	}
+
+	for statusCode, summary := range tests {
+		t.Run(summary, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			result := DiagnoseResponse(&diags, "reading", "repository", "maven-releases", testAPIResponse(statusCode, "details"), errors.New(http.StatusText(statusCode)))
+
+			if result != statusCode {
+				t.Fatalf("DiagnoseResponse() = %d, expected %d", result, statusCode)
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != summary {
+				t.Fatalf("expected a single %q error, got: %v", summary, diags)
+			}
+		})
+	}
+}
+
+// TestDiagnoseResponse_Success tests that successful responses add no diagnostics
+func TestDiagnoseResponse_Success(t *testing.T) {
+	diags := diag.Diagnostics{}
+
+	if result := DiagnoseResponse(&diags, "reading", "repository", "r", testAPIResponse(http.StatusOK, "{}"), nil); result != http.StatusOK {
+		t.Fatalf("DiagnoseResponse() = %d, expected 200", result)
+	}
+	if DiagnoseResponse(&diags, "reading", "repository", "r", nil, nil) != 0 || diags.HasError() {
+		t.Fatalf("expected no diagnostics, got: %v", diags)
+	}
+}
+
+// TestDiagnoseResponse_NetworkError tests that failures without a response are reported as network errors
+func TestDiagnoseResponse_NetworkError(t *testing.T) {
+	diags := diag.Diagnostics{}
+
+	DiagnoseResponse(&diags, "reading", "repository", "r", nil, testURLError(syscall.ECONNREFUSED))
+
+	if diags.Errors()[0].Summary() != "Connection Refused" {
+		t.Fatalf("unexpected summary: %q", diags.Errors()[0].Summary())
+	}
+}
+
+// TestDiagnoseResponse_ResponseError tests that the status is taken from a ResponseError without a response
+func TestDiagnoseResponse_ResponseError(t *testing.T) {
+	diags := diag.Diagnostics{}
+	err := &ResponseError{StatusCode: http.StatusForbidden, Status: "403 Forbidden", RequestID: "req-9"}
+
+	DiagnoseResponse(&diags, "deleting", "role", "admin", nil, err)
+
+	if diags.Errors()[0].Summary() != "Forbidden deleting role" || !strings.Contains(diags.Errors()[0].Detail(), "(request ID: req-9)") {
+		t.Fatalf("unexpected diagnostic: %v", diags)
+	}
+}
+
+// TestResponseDiagnoser_Overrides tests per-resource status overrides
+func TestResponseDiagnoser_Overrides(t *testing.T) {
+	diagnoser := ResponseDiagnoser{
+		Overrides: []StatusOverride{NotFoundWhenBodyContains(http.StatusBadRequest, "does not exist")},
+	}
+
+	if status := diagnoser.EffectiveStatus(testAPIResponse(http.StatusBadRequest, "Repository Does Not Exist"), nil); status != http.StatusNotFound {
+		t.Fatalf("EffectiveStatus() = %d, expected 404", status)
+	}
+
+	diags := diag.Diagnostics{}
+	resp := testAPIResponse(http.StatusBadRequest, "Repository does not exist")
+	diagnoser.Diagnose(&diags, "reading", "repository", "maven-releases", resp, nil)
+	if diags.Errors()[0].Summary() != "repository Not Found" {
+		t.Fatalf("unexpected summary: %q", diags.Errors()[0].Summary())
+	}
+
+	diags = diag.Diagnostics{}
+	diagnoser.Diagnose(&diags, "reading", "repository", "maven-releases", testAPIResponse(http.StatusBadRequest, "invalid name"), nil)
+	if diags.Errors()[0].Summary() != "Client Error (400)" {
+		t.Fatalf("unexpected summary: %q", diags.Errors()[0].Summary())
+	}
+}
+
+// TestResponseDiagnoser_FieldErrors tests that validation errors are reported on attributes
+func TestResponseDiagnoser_FieldErrors(t *testing.T) {
+	diagnoser := ResponseDiagnoser{Fields: FieldPaths{"name": path.Root("name")}}
+	diags := diag.Diagnostics{}
+
+	diagnoser.Diagnose(&diags, "creating", "role", "", testAPIResponse(http.StatusBadRequest, `[{"id":"PARAMETER name","message":"must not be blank"}]`), nil)
+
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("name")) || diags.Errors()[0].Summary() != "Error creating role" {
+		t.Fatalf("expected an attribute error on name, got: %v", diags)
+	}
+}
@@ -17,17 +17,13 @@
 package errors
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net"
 	"net/http"
 	"reflect"
-	"syscall"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
 )
 
 // ErrorMessage is a standardized error message structure
@@ -37,8 +33,15 @@ type ErrorMessage struct {
 	ErrorDetail string
 }
 
-// APIError creates a standardized error for API operations
+// APIError creates a standardized error for API operations.
+//
+// Deprecated: use APIErrorMessage. ResponseError is the error type for failed API calls.
 func APIError(operation string, resourceType string, details string) (string, string) {
+	return APIErrorMessage(operation, resourceType, details)
+}
+
+// APIErrorMessage creates a standardized error for API operations
+func APIErrorMessage(operation string, resourceType string, details string) (string, string) {
 	title := fmt.Sprintf("Error %s %s", operation, resourceType)
 	message := fmt.Sprintf("Could not %s %s: %s", operation, resourceType, details)
 	return title, message
@@ -79,18 +82,22 @@ func TimeoutError(operation string, resourceType string) (string, string) {
 	return title, message
 }
 
-// ParseAPIError extracts error information from an HTTP response
+// ParseAPIError extracts error information from an HTTP response, with registered secrets redacted
 func ParseAPIError(response *http.Response) string {
+	return redact.String(parseAPIError(response, nil))
+}
+
+// parseAPIError extracts error information from an HTTP response, warning when the body cannot be read cleanly
+func parseAPIError(response *http.Response, diags *diag.Diagnostics) string {
 	if response == nil {
 		return "Unknown error"
 	}
 
 	// Try to read the response body for error details
-	if response.Body != nil {
-		body, err := io.ReadAll(response.Body)
-		if err == nil && len(body) > 0 {
-			return fmt.Sprintf("%s: %s", response.Status, string(body))
-		}
+	captured := CaptureBody(response, MaxBodyCaptureSize)
+	captured.AddWarning(diags)
+	if len(captured.Bytes) > 0 {
+		return fmt.Sprintf("%s: %s", response.Status, captured)
 	}
 
 	return response.Status
@@ -98,63 +105,79 @@ func ParseAPIError(response *http.Response) string {
 
 // AddAPIErrorDiagnostic adds a standardized API error to diagnostics
 func AddAPIErrorDiagnostic(diags *diag.Diagnostics, operation string, resourceType string, response *http.Response, originalErr error) {
-	title, baseMessage := APIError(operation, resourceType, "")
-	details := ParseAPIError(response)
+	title, baseMessage := APIErrorMessage(operation, resourceType, "")
+	var details string
+	code := CodeAPIError
+	wait := rateLimitWaitOf(response, originalErr)
+	if apiErr, ok := AsResponseError(originalErr); ok {
+		details = apiErr.details()
+		originalErr = apiErr.Err
+		code = CodeForStatus(apiErr.StatusCode)
+	} else {
+		details = withRequestID(parseAPIError(response, diags), requestID(response))
+		if response != nil {
+			code = CodeForStatus(response.StatusCode)
+		}
+	}
 	if originalErr != nil {
 		details = fmt.Sprintf("%s: %v", details, originalErr)
 	}
-	diags.AddError(title, fmt.Sprintf("%s %s", baseMessage, details))
+	if code == CodeRateLimited {
+		AddRateLimitedDiagnostic(diags, operation, resourceType, wait, details)
+		return
+	}
+	addError(diags, code, title, fmt.Sprintf("%s %s", baseMessage, details))
 }
 
 // AddNotFoundDiagnostic adds a standardized not found error to diagnostics
 func AddNotFoundDiagnostic(diags *diag.Diagnostics, resourceType string, resourceID string) {
 	title, message := NotFoundError(resourceType, resourceID)
-	diags.AddError(title, message)
+	addError(diags, CodeNotFound, title, message)
 }
 
 // AddValidationDiagnostic adds a standardized validation error to diagnostics
 func AddValidationDiagnostic(diags *diag.Diagnostics, field string, reason string) {
 	title, message := ValidationError(field, reason)
-	diags.AddError(title, message)
+	addError(diags, CodeValidation, title, message)
 }
 
 // AddConflictDiagnostic adds a standardized conflict error to diagnostics
 func AddConflictDiagnostic(diags *diag.Diagnostics, resourceType string, details string) {
 	title, message := ConflictError(resourceType, details)
-	diags.AddError(title, message)
+	addError(diags, CodeConflict, title, message)
 }
 
 // AddUnauthorizedDiagnostic adds a standardized unauthorized error to diagnostics
 func AddUnauthorizedDiagnostic(diags *diag.Diagnostics, operation string) {
 	title, message := UnauthorizedError(operation)
-	diags.AddError(title, message)
+	addError(diags, CodeUnauthorized, title, message)
 }
 
 // AddTimeoutDiagnostic adds a standardized timeout error to diagnostics
 func AddTimeoutDiagnostic(diags *diag.Diagnostics, operation string, resourceType string) {
 	title, message := TimeoutError(operation, resourceType)
-	diags.AddError(title, message)
+	addError(diags, CodeTimeout, title, message)
 }
 
 // AddForbiddenDiagnostic adds a standardized 403 Forbidden error to diagnostics
 func AddForbiddenDiagnostic(diags *diag.Diagnostics, operation string) {
 	title := fmt.Sprintf("Forbidden %s", operation)
 	message := fmt.Sprintf("You do not have permission to %s (HTTP 403). Please check your access credentials and permissions.", operation)
-	diags.AddError(title, message)
+	addError(diags, CodeForbidden, title, message)
 }
 
 // AddServerErrorDiagnostic adds a standardized 5xx error to diagnostics
 func AddServerErrorDiagnostic(diags *diag.Diagnostics, message string, statusCode int) {
 	title := fmt.Sprintf("Server Error (%d)", statusCode)
 	details := fmt.Sprintf("The server returned an error (HTTP %d). Details: %s", statusCode, message)
-	diags.AddError(title, details)
+	addError(diags, CodeServerError, title, details)
 }
 
 // AddClientErrorDiagnostic adds a standardized 4xx error to diagnostics
 func AddClientErrorDiagnostic(diags *diag.Diagnostics, message string, statusCode int) {
 	title := fmt.Sprintf("Client Error (%d)", statusCode)
 	details := fmt.Sprintf("The request could not be processed (HTTP %d). Details: %s", statusCode, message)
-	diags.AddError(title, details)
+	addError(diags, CodeClientError, title, details)
 }
 
 // IsNotFound checks if an HTTP response is a 404
@@ -192,22 +215,36 @@ func IsServerError(statusCode int) bool {
 // This is useful for providers that need to distinguish between network errors
 // and API errors during resource operations.
 func HandleAPIError(message string, err *error, httpResponse *http.Response, respDiags *diag.Diagnostics) {
-	networkError, errorMessage := detectNetworkError(*err)
-	if networkError {
-		respDiags.AddError(
-			errorMessage,
-			fmt.Sprintf("Networking Error: %s (%v)", errorMessage, *err),
+	if statusCode := rateLimitStatus(httpResponse, *err); statusCode >= 0 {
+		details := withRetryCount(fmt.Sprintf("%s: %v", message, *err), *err, httpResponse)
+		addRateLimited(respDiags, statusCode, "", rateLimitWaitOf(httpResponse, *err), details)
+	} else if networkErr := ClassifyNetworkError(*err); networkErr != nil {
+		addError(
+			respDiags,
+			CodeNetworkError,
+			networkErr.Summary(),
+			withRetryCount(networkErr.Detail(), *err, httpResponse),
 		)
+	} else if apiErr, ok := AsResponseError(*err); ok {
+		details := apiErr.details()
+		if apiErr.Err != nil {
+			details = fmt.Sprintf("%v: %s", apiErr.Err, details)
+		}
+		addError(respDiags, CodeForStatus(apiErr.StatusCode), message, withRetryCount(details, *err, httpResponse))
 	} else {
 		if httpResponse != nil {
-			respDiags.AddError(
+			addError(
+				respDiags,
+				CodeForStatus(httpResponse.StatusCode),
 				message,
-				fmt.Sprintf("%s: %s: %s", *err, httpResponse.Status, extractResponseBody(httpResponse)),
+				withRetryCount(withRequestID(fmt.Sprintf("%s: %s: %s", *err, httpResponse.Status, extractResponseBody(httpResponse, respDiags)), requestID(httpResponse)), *err, httpResponse),
 			)
 		} else {
-			respDiags.AddError(
+			addError(
+				respDiags,
+				CodeAPIError,
 				message,
-				fmt.Sprintf("Unexpected Error: %v ('%s'): ", *err, reflect.TypeOf(*err)),
+				withRetryCount(fmt.Sprintf("Unexpected Error: %v ('%s'): ", *err, reflect.TypeOf(*err)), *err, httpResponse),
 			)
 		}
 	}
@@ -220,49 +257,18 @@ func HandleAPIWarning(message string, err *error, httpResponse *http.Response, r
 	if httpResponse != nil {
 		status = httpResponse.Status
 	}
-	respDiags.AddWarning(
+	addWarning(
+		respDiags,
+		CodeAPIWarning,
 		message,
-		fmt.Sprintf("%s: %s: %s", *err, status, extractResponseBody(httpResponse)),
+		fmt.Sprintf("%s: %s: %s", *err, status, extractResponseBody(httpResponse, respDiags)),
 	)
 }
 
-// detectNetworkError identifies if an error is network-related (as opposed to API-related)
-// It checks for specific error types like DNS errors, connection errors, and timeouts.
-func detectNetworkError(err error) (bool, string) {
-	// Check for specific error types
-	if opErr, ok := err.(*net.OpError); ok {
-		// Network operation error (dial, read, write, etc.)
-		return true, fmt.Sprintf("OpError: %s, %s", opErr.Op, opErr.Net)
-	}
-
-	if dnsErr, ok := err.(*net.DNSError); ok {
-		// DNS resolution error
-		return true, fmt.Sprintf("DNS Error: %v", dnsErr)
-	}
-
-	if errors.Is(err, context.DeadlineExceeded) {
-		// Timeout error
-		return true, fmt.Sprintf("Connection timed out: %v", err)
-	}
-
-	// General network error check
-	if errors.Is(err, syscall.ECONNREFUSED) {
-		return true, fmt.Sprintf("Connection refused: %v", err)
-	}
-
-	return false, ""
-}
-
-// extractResponseBody reads and returns the body from an HTTP response.
-// This is a helper function for error reporting.
-func extractResponseBody(httpResponse *http.Response) []byte {
-	if httpResponse == nil || httpResponse.Body == nil {
-		return []byte{}
-	}
-	body, _ := io.ReadAll(httpResponse.Body)
-	err := httpResponse.Body.Close()
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	return body
+// extractResponseBody captures the body from an HTTP response for error reporting.
+// The body stays readable afterwards and problems reading or closing it are reported as warnings.
+func extractResponseBody(httpResponse *http.Response, respDiags *diag.Diagnostics) string {
+	captured := CaptureBody(httpResponse, MaxBodyCaptureSize)
+	captured.AddWarning(respDiags)
+	return captured.String()
 }
@@ -207,7 +207,7 @@ func TestIsServerError(t *testing.T) {
 	}
 }
 
-// TestAPIError tests the APIError function
+// TestAPIError tests the deprecated APIError function
 func TestAPIError(t *testing.T) {
 	title, message := APIError("create", "User", "invalid credentials")
 
@@ -220,6 +220,19 @@ func TestAPIError(t *testing.T) {
 	}
 }
 
+// TestAPIErrorMessage tests the APIErrorMessage function
+func TestAPIErrorMessage(t *testing.T) {
+	title, message := APIErrorMessage("create", "User", "invalid credentials")
+
+	if title != "Error create User" {
+		t.Fatalf("Expected title 'Error create User', got '%s'", title)
+	}
+
+	if message != "Could not create User: invalid credentials" {
+		t.Fatalf("Expected message 'Could not create User: invalid credentials', got '%s'", message)
+	}
+}
+
 // TestNotFoundError tests the NotFoundError function
 func TestNotFoundError(t *testing.T) {
 	title, message := NotFoundError("User", "123")
@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyForRequest returns the proxy a request is sent through, or nil when it is sent directly. A bare
+// HTTP status text is only classified as a proxy refusing CONNECT when a proxy is used for the request.
+// It reads HTTPS_PROXY, HTTP_PROXY and NO_PROXY; set it to client.ProxyConfig.ProxyFunc when the
+// client is given an explicit proxy.
+var ProxyForRequest = func(req *http.Request) (*url.URL, error) {
+	return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
+}
+
+// NetworkErrorCategory classifies a failure to reach the API
+type NetworkErrorCategory int
+
+const (
+	// NetworkErrorUnknown is a network failure that fits no other category
+	NetworkErrorUnknown NetworkErrorCategory = iota
+	// NetworkErrorDNS means the server hostname could not be resolved
+	NetworkErrorDNS
+	// NetworkErrorConnectionRefused means nothing is listening on the server address
+	NetworkErrorConnectionRefused
+	// NetworkErrorConnectionReset means the connection was closed by the server or something in between
+	NetworkErrorConnectionReset
+	// NetworkErrorUnexpectedEOF means the connection ended before a complete response was received
+	NetworkErrorUnexpectedEOF
+	// NetworkErrorTimeout means the server did not respond in time
+	NetworkErrorTimeout
+	// NetworkErrorProxy means the request could not be sent through the configured proxy
+	NetworkErrorProxy
+	// NetworkErrorUnknownAuthority means the server certificate is signed by an untrusted CA
+	NetworkErrorUnknownAuthority
+	// NetworkErrorHostnameMismatch means the server certificate is not valid for the requested host
+	NetworkErrorHostnameMismatch
+	// NetworkErrorCertificateInvalid means the server certificate is expired or otherwise invalid
+	NetworkErrorCertificateInvalid
+	// NetworkErrorTLSHandshake means the TLS handshake with the server failed
+	NetworkErrorTLSHandshake
+)
+
+// networkErrorInfo describes a category for diagnostics
+type networkErrorInfo struct {
+	name        string
+	summary     string
+	description string
+	remediation string
+}
+
+var networkErrorInfos = map[NetworkErrorCategory]networkErrorInfo{
+	NetworkErrorUnknown: {
+		"unknown", "Network Error",
+		"the request failed before a response was received",
+		"check the `url` setting and that the server can be reached from this machine",
+	},
+	NetworkErrorDNS: {
+		"dns", "DNS Lookup Failed",
+		"the server hostname could not be resolved",
+		"check the hostname in the `url` setting and your DNS configuration",
+	},
+	NetworkErrorConnectionRefused: {
+		"connection_refused", "Connection Refused",
+		"the server refused the connection",
+		"check that the server is running and that the port in the `url` setting is correct",
+	},
+	NetworkErrorConnectionReset: {
+		"connection_reset", "Connection Reset",
+		"the connection was reset by the server or a proxy or load balancer in between",
+		"check the server and any proxy logs, then retry",
+	},
+	NetworkErrorUnexpectedEOF: {
+		"unexpected_eof", "Connection Closed Unexpectedly",
+		"the connection closed before a complete response was received",
+		"check that the `url` scheme matches the server (http or https) and that no proxy is cutting the connection",
+	},
+	NetworkErrorTimeout: {
+		"timeout", "Connection Timed Out",
+		"the server did not respond in time",
+		"check that the server is reachable and not overloaded, or increase the timeout",
+	},
+	NetworkErrorProxy: {
+		"proxy", "Proxy Connection Failed",
+		"the request could not be sent through the proxy",
+		"check the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables and that the proxy allows CONNECT to the server",
+	},
+	NetworkErrorUnknownAuthority: {
+		"unknown_authority", "Untrusted Server Certificate",
+		"certificate signed by unknown authority",
+		"set `ca_cert_file` or `insecure_skip_verify`",
+	},
+	NetworkErrorHostnameMismatch: {
+		"hostname_mismatch", "Server Certificate Hostname Mismatch",
+		"the server certificate is not valid for the host in the `url` setting",
+		"use a hostname listed in the certificate, or set `insecure_skip_verify`",
+	},
+	NetworkErrorCertificateInvalid: {
+		"certificate_invalid", "Invalid Server Certificate",
+		"the server certificate is expired, not yet valid or otherwise invalid",
+		"renew the server certificate and check the system clock, or set `insecure_skip_verify`",
+	},
+	NetworkErrorTLSHandshake: {
+		"tls_handshake", "TLS Handshake Failed",
+		"the TLS handshake with the server failed",
+		"check that the `url` scheme matches the server (http or https) and that the server supports a TLS version allowed by the provider",
+	},
+}
+
+// String returns a stable name for the category
+func (c NetworkErrorCategory) String() string {
+	return networkErrorInfos[c].name
+}
+
+// NetworkError is a classified failure to reach the API
+type NetworkError struct {
+	Category NetworkErrorCategory
+	Err      error
+}
+
+// Error implements the error interface
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("%s: %v", networkErrorInfos[e.Category].description, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// Summary returns the diagnostic summary for the failure
+func (e *NetworkError) Summary() string {
+	return networkErrorInfos[e.Category].summary
+}
+
+// Remediation returns a hint on how to fix the failure
+func (e *NetworkError) Remediation() string {
+	return networkErrorInfos[e.Category].remediation
+}
+
+// Detail returns the diagnostic detail for the failure, including the remediation hint
+func (e *NetworkError) Detail() string {
+	info := networkErrorInfos[e.Category]
+	return fmt.Sprintf("Networking Error: %s — %s (%v)", info.description, info.remediation, e.Err)
+}
+
+// ClassifyNetworkError inspects the whole error chain and returns the network failure it describes,
+// or nil when the error is not network related
+func ClassifyNetworkError(err error) *NetworkError {
+	if err == nil {
+		return nil
+	}
+	if category, ok := networkErrorCategory(err); ok {
+		return &NetworkError{Category: category, Err: err}
+	}
+	return nil
+}
+
+// networkErrorCategory finds the most specific category for an error chain
+func networkErrorCategory(err error) (NetworkErrorCategory, bool) {
+	var classified *NetworkError
+	if errors.As(err, &classified) {
+		return classified.Category, true
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	if errors.As(err, &unknownAuthority) {
+		return NetworkErrorUnknownAuthority, true
+	}
+	var hostname x509.HostnameError
+	if errors.As(err, &hostname) {
+		return NetworkErrorHostnameMismatch, true
+	}
+	var invalid x509.CertificateInvalidError
+	var verification *tls.CertificateVerificationError
+	if errors.As(err, &invalid) || errors.As(err, &verification) {
+		return NetworkErrorCertificateInvalid, true
+	}
+	var recordHeader tls.RecordHeaderError
+	var alert tls.AlertError
+	if errors.As(err, &recordHeader) || errors.As(err, &alert) {
+		return NetworkErrorTLSHandshake, true
+	}
+	// net/http and crypto/tls report most handshake failures as plain errors
+	var urlErr *url.Error
+	isURLErr := errors.As(err, &urlErr)
+	if isURLErr && (strings.Contains(err.Error(), "tls: ") || strings.Contains(err.Error(), "server gave HTTP response to HTTPS client")) {
+		return NetworkErrorTLSHandshake, true
+	}
+
+	var opErr *net.OpError
+	if (errors.As(err, &opErr) && opErr.Op == "proxyconnect") || (isURLErr && isRefusedProxyConnect(urlErr)) {
+		return NetworkErrorProxy, true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return NetworkErrorDNS, true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return NetworkErrorConnectionRefused, true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return NetworkErrorConnectionReset, true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NetworkErrorTimeout, true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return NetworkErrorTimeout, true
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || (isURLErr && errors.Is(err, io.EOF)) {
+		return NetworkErrorUnexpectedEOF, true
+	}
+	if opErr != nil {
+		return NetworkErrorUnknown, true
+	}
+
+	return NetworkErrorUnknown, false
+}
+
+// isRefusedProxyConnect reports whether the error is the one net/http returns when a proxy answers
+// CONNECT with a status other than 200: the bare status text, e.g. "Proxy Authentication Required",
+// without any wrapping. CONNECT is only used for https URLs sent through a proxy.
+func isRefusedProxyConnect(urlErr *url.Error) bool {
+	if !strings.HasPrefix(urlErr.URL, "https://") || urlErr.Err == nil || errors.Unwrap(urlErr.Err) != nil {
+		return false
+	}
+	target, err := url.Parse(urlErr.URL)
+	if err != nil {
+		return false
+	}
+	if proxy, err := ProxyForRequest(&http.Request{URL: target}); err != nil || proxy == nil {
+		return false
+	}
+	text := urlErr.Err.Error()
+	if text == "unknown status code" {
+		return true
+	}
+	for statusCode := 300; statusCode < 600; statusCode++ {
+		if http.StatusText(statusCode) == text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// withoutProxy clears the proxy environment variables for the test
+func withoutProxy(t *testing.T) {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+}
+
+func testURLError(err error) error {
+	return &url.Error{Op: "Get", URL: "https://nexus.example.com/service/rest/v1/status", Err: err}
+}
+
+// TestClassifyNetworkError tests the ClassifyNetworkError function
+func TestClassifyNetworkError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected NetworkErrorCategory
+	}{
+		"dns": {
+			testURLError(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "nexus.example.com"}}),
+			NetworkErrorDNS,
+		},
+		"reset": {
+			testURLError(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}),
+			NetworkErrorConnectionReset,
+		},
+		"unexpected eof": {
+			fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF),
+			NetworkErrorUnexpectedEOF,
+		},
+		"eof from transport": {
+			testURLError(io.EOF),
+			NetworkErrorUnexpectedEOF,
+		},
+		"deadline": {
+			testURLError(context.DeadlineExceeded),
+			NetworkErrorTimeout,
+		},
+		"hostname mismatch": {
+			testURLError(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "nexus.internal"}),
+			NetworkErrorHostnameMismatch,
+		},
+		"expired certificate": {
+			testURLError(x509.CertificateInvalidError{Reason: x509.Expired}),
+			NetworkErrorCertificateInvalid,
+		},
+		"remote alert": {
+			testURLError(&net.OpError{Op: "remote error", Err: errors.New("tls: handshake failure")}),
+			NetworkErrorTLSHandshake,
+		},
+		"other op error": {
+			testURLError(&net.OpError{Op: "write", Net: "tcp", Err: errors.New("broken")}),
+			NetworkErrorUnknown,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			networkErr := ClassifyNetworkError(test.err)
+			if networkErr == nil {
+				t.Fatalf("ClassifyNetworkError(%v) = nil, expected %s", test.err, test.expected)
+			}
+			if networkErr.Category != test.expected {
+				t.Fatalf("Category = %s, expected %s", networkErr.Category, test.expected)
+			}
+			if !errors.Is(networkErr, test.err) {
+				t.Fatal("NetworkError should unwrap to the original error")
+			}
+		})
+	}
+}
+
+// TestClassifyNetworkError_NotNetwork tests that API errors are not classified as network errors
+func TestClassifyNetworkError_NotNetwork(t *testing.T) {
+	for _, err := range []error{nil, errors.New("400 Bad Request"), errors.New("Forbidden"), http.ErrUseLastResponse, io.EOF} {
+		if networkErr := ClassifyNetworkError(err); networkErr != nil {
+			t.Fatalf("ClassifyNetworkError(%v) = %s, expected nil", err, networkErr.Category)
+		}
+	}
+}
+
+// TestClassifyNetworkError_Live tests classification of errors returned by a real HTTP client
+func TestClassifyNetworkError_Live(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer plainServer.Close()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+
+	tests := map[string]struct {
+		url      string
+		expected NetworkErrorCategory
+	}{
+		"unknown authority": {tlsServer.URL, NetworkErrorUnknownAuthority},
+		"https to http":     {strings.Replace(plainServer.URL, "http://", "https://", 1), NetworkErrorTLSHandshake},
+		"refused":           {"http://" + closedAddress, NetworkErrorConnectionRefused},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := http.Get(test.url)
+			networkErr := ClassifyNetworkError(err)
+			if networkErr == nil || networkErr.Category != test.expected {
+				t.Fatalf("ClassifyNetworkError(%v) = %v, expected %s", err, networkErr, test.expected)
+			}
+		})
+	}
+}
+
+// TestClassifyNetworkError_Proxy tests classification of errors returned when requests go through a real proxy
+func TestClassifyNetworkError_Proxy(t *testing.T) {
+	refusingProxy := func(statusCode int) string {
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodConnect {
+				t.Errorf("proxy received %s, expected CONNECT", r.Method)
+			}
+			w.WriteHeader(statusCode)
+		}))
+		t.Cleanup(proxy.Close)
+		return proxy.URL
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedProxy := "http://" + listener.Addr().String()
+	listener.Close()
+
+	tests := map[string]string{
+		"authentication required": refusingProxy(http.StatusProxyAuthRequired),
+		"forbidden":               refusingProxy(http.StatusForbidden),
+		"bad gateway":             refusingProxy(http.StatusBadGateway),
+		"proxy unreachable":       closedProxy,
+	}
+
+	for name, proxy := range tests {
+		t.Run(name, func(t *testing.T) {
+			withoutProxy(t)
+			t.Setenv("HTTPS_PROXY", proxy)
+			client := &http.Client{Transport: &http.Transport{Proxy: ProxyForRequest}}
+
+			_, err = client.Get("https://nexus.example.com/service/rest/v1/status")
+			networkErr := ClassifyNetworkError(err)
+			if networkErr == nil || networkErr.Category != NetworkErrorProxy {
+				t.Fatalf("ClassifyNetworkError(%v) = %v, expected %s", err, networkErr, NetworkErrorProxy)
+			}
+		})
+	}
+}
+
+// TestClassifyNetworkError_StatusTextWithoutProxy tests that a bare status text is not blamed on a proxy
+// when the request is sent directly
+func TestClassifyNetworkError_StatusTextWithoutProxy(t *testing.T) {
+	err := testURLError(errors.New(http.StatusText(http.StatusForbidden)))
+
+	withoutProxy(t)
+	if networkErr := ClassifyNetworkError(err); networkErr != nil && networkErr.Category == NetworkErrorProxy {
+		t.Fatalf("ClassifyNetworkError(%v) = %s without a proxy", err, networkErr.Category)
+	}
+
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("NO_PROXY", ".example.com")
+	if networkErr := ClassifyNetworkError(err); networkErr != nil && networkErr.Category == NetworkErrorProxy {
+		t.Fatalf("ClassifyNetworkError(%v) = %s for a host in NO_PROXY", err, networkErr.Category)
+	}
+
+	t.Setenv("NO_PROXY", "")
+	if networkErr := ClassifyNetworkError(err); networkErr == nil || networkErr.Category != NetworkErrorProxy {
+		t.Fatalf("ClassifyNetworkError(%v) = %v, expected %s through a proxy", err, networkErr, NetworkErrorProxy)
+	}
+}
+
+// TestHandleAPIError_NetworkRemediation tests that network diagnostics include a remediation hint
+func TestHandleAPIError_NetworkRemediation(t *testing.T) {
+	diags := diag.Diagnostics{}
+	err := testURLError(x509.UnknownAuthorityError{})
+
+	HandleAPIError("Error reading repository", &err, nil, &diags)
+
+	if diags.Errors()[0].Summary() != "Untrusted Server Certificate" {
+		t.Fatalf("unexpected summary: %q", diags.Errors()[0].Summary())
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), "certificate signed by unknown authority — set `ca_cert_file` or `insecure_skip_verify`") {
+		t.Fatalf("detail should include the remediation hint: %q", diags.Errors()[0].Detail())
+	}
+}
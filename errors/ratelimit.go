@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// RateLimitResetHeaders are the response headers checked, in order, for the seconds until the rate limit resets
+var RateLimitResetHeaders = []string{"RateLimit-Reset", "X-RateLimit-Reset", "X-Rate-Limit-Reset"}
+
+// RateLimitRemainingHeaders are the response headers checked, in order, for the requests left in the current window
+var RateLimitRemainingHeaders = []string{"RateLimit-Remaining", "X-RateLimit-Remaining", "X-Rate-Limit-Remaining"}
+
+// RateLimitError is returned when a request is not sent because the client-side rate limiter
+// would have to wait longer than the request context allows
+type RateLimitError struct {
+	// Wait is how long the request would have had to wait
+	Wait time.Duration
+	Err  error
+}
+
+// Error implements the error interface
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited for %s: %v", e.Wait.Round(time.Second), e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// AsRateLimitError finds the first RateLimitError in the error chain
+func AsRateLimitError(err error) (*RateLimitError, bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr, true
+	}
+	return nil, false
+}
+
+// ParseRetryAfter parses a Retry-After header given either as seconds or as an HTTP date
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// RateLimitWait returns how long the server asked the client to wait, from the Retry-After header or,
+// when the current window is used up, the rate limit reset headers
+func RateLimitWait(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+	if wait, ok := ParseRetryAfter(header.Get("Retry-After"), now); ok {
+		return wait, true
+	}
+	if remaining, ok := firstInt(header, RateLimitRemainingHeaders); !ok || remaining > 0 {
+		return 0, false
+	}
+	if reset, ok := firstInt(header, RateLimitResetHeaders); ok {
+		return max(time.Duration(reset)*time.Second, 0), true
+	}
+	return 0, false
+}
+
+// firstInt returns the first of the headers holding an integer
+func firstInt(header http.Header, names []string) (int, bool) {
+	for _, name := range names {
+		if value, err := strconv.Atoi(header.Get(name)); err == nil {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// AddRateLimitedDiagnostic adds a diagnostic for a request rejected by the server's rate limiter,
+// including how long to wait when known
+func AddRateLimitedDiagnostic(diags *diag.Diagnostics, operation string, resourceType string, wait time.Duration, details string) {
+	addRateLimited(diags, http.StatusTooManyRequests, fmt.Sprintf("while %s %s ", operation, resourceType), wait, details)
+}
+
+// addRateLimited adds the rate limited diagnostic for a 429 response, or for the client-side limiter when statusCode is 0
+func addRateLimited(diags *diag.Diagnostics, statusCode int, during string, wait time.Duration, details string) {
+	advice := "Retry later"
+	if wait > 0 {
+		advice = fmt.Sprintf("Retry in %s", wait.Round(time.Second))
+	}
+	var message string
+	if statusCode == 0 {
+		message = fmt.Sprintf("The provider's rate limiter could not send the request %swithin the operation timeout. %s, reduce parallelism (terraform apply -parallelism=N) or raise the provider request rate.", during, advice)
+	} else {
+		message = fmt.Sprintf("The server is rate limiting requests %s(HTTP %d). %s, reduce parallelism (terraform apply -parallelism=N) or lower the provider request rate.", during, statusCode, advice)
+	}
+	if details != "" {
+		message = fmt.Sprintf("%s Details: %s", message, details)
+	}
+	addError(diags, CodeRateLimited, "Rate Limited", message)
+}
+
+// rateLimitWaitOf returns the wait requested by the response or recorded on a ResponseError or RateLimitError
+func rateLimitWaitOf(httpResponse *http.Response, err error) time.Duration {
+	if httpResponse != nil {
+		if wait, ok := RateLimitWait(httpResponse.Header, time.Now()); ok {
+			return wait
+		}
+	}
+	if rateLimitErr, ok := AsRateLimitError(err); ok {
+		return rateLimitErr.Wait
+	}
+	if apiErr, ok := AsResponseError(err); ok {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// rateLimitStatus returns 429 when the server rate limited the request, 0 when the client-side limiter did,
+// and -1 otherwise
+func rateLimitStatus(httpResponse *http.Response, err error) int {
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusTooManyRequests {
+		return http.StatusTooManyRequests
+	}
+	if StatusCode(err) == http.StatusTooManyRequests {
+		return http.StatusTooManyRequests
+	}
+	if _, ok := AsRateLimitError(err); ok {
+		return 0
+	}
+	return -1
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// TestParseRetryAfter tests parsing Retry-After as seconds and as an HTTP date
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if wait, ok := ParseRetryAfter("120", now); !ok || wait != 2*time.Minute {
+		t.Fatalf("ParseRetryAfter(120) = %s, %v, expected 2m", wait, ok)
+	}
+	if wait, ok := ParseRetryAfter("Wed, 01 Jan 2025 12:00:30 GMT", now); !ok || wait != 30*time.Second {
+		t.Fatalf("ParseRetryAfter(date) = %s, %v, expected 30s", wait, ok)
+	}
+	if _, ok := ParseRetryAfter("soon", now); ok {
+		t.Fatal("ParseRetryAfter should reject an invalid value")
+	}
+}
+
+// TestRateLimitWait tests reading the wait from Retry-After and rate limit reset headers
+func TestRateLimitWait(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		"retry after":         {http.Header{"Retry-After": []string{"5"}}, 5 * time.Second, true},
+		"window used up":      {http.Header{"Ratelimit-Remaining": []string{"0"}, "Ratelimit-Reset": []string{"20"}}, 20 * time.Second, true},
+		"window not used up":  {http.Header{"X-Ratelimit-Remaining": []string{"3"}, "X-Ratelimit-Reset": []string{"20"}}, 0, false},
+		"reset without count": {http.Header{"X-Ratelimit-Reset": []string{"20"}}, 0, false},
+		"no headers":          {http.Header{}, 0, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			wait, ok := RateLimitWait(test.header, now)
+			if wait != test.expected || ok != test.ok {
+				t.Fatalf("RateLimitWait() = %s, %v, expected %s, %v", wait, ok, test.expected, test.ok)
+			}
+		})
+	}
+}
+
+// TestRateLimitedDiagnostic tests that 429 responses produce a Rate Limited diagnostic with the wait time
+func TestRateLimitedDiagnostic(t *testing.T) {
+	rateLimited := func() *http.Response {
+		resp := testAPIResponse(http.StatusTooManyRequests, "slow down")
+		resp.Header.Set("Retry-After", "90")
+		return resp
+	}
+	err := error(NewResponseError(rateLimited(), nil))
+
+	tests := map[string]func(diags *diag.Diagnostics){
+		"DiagnoseResponse": func(diags *diag.Diagnostics) {
+			DiagnoseResponse(diags, "creating", "application", "app", rateLimited(), nil)
+		},
+		"DiagnoseResponse with ResponseError": func(diags *diag.Diagnostics) {
+			DiagnoseResponse(diags, "creating", "application", "app", nil, err)
+		},
+		"AddAPIErrorDiagnostic": func(diags *diag.Diagnostics) {
+			AddAPIErrorDiagnostic(diags, "creating", "application", rateLimited(), nil)
+		},
+		"HandleAPIError": func(diags *diag.Diagnostics) {
+			HandleAPIError("Error creating application", &err, nil, diags)
+		},
+	}
+	for name, add := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			add(&diags)
+			if diags.ErrorsCount() != 1 || diags[0].Summary() != "Rate Limited" {
+				t.Fatalf("expected a single Rate Limited error, got: %v", diags)
+			}
+			if !strings.Contains(diags[0].Detail(), "Retry in 1m30s") || !strings.Contains(diags[0].Detail(), "Error code: SONA-E-429") {
+				t.Fatalf("expected the wait time and code in the detail, got: %q", diags[0].Detail())
+			}
+		})
+	}
+}
+
+// TestRateLimitedDiagnostic_ClientSide tests the diagnostic for requests held back by the client-side limiter
+func TestRateLimitedDiagnostic_ClientSide(t *testing.T) {
+	var err error = &RateLimitError{Wait: 45 * time.Second, Err: context.DeadlineExceeded}
+	diags := diag.Diagnostics{}
+	HandleAPIError("Error creating application", &err, nil, &diags)
+
+	if diags.ErrorsCount() != 1 || diags[0].Summary() != "Rate Limited" {
+		t.Fatalf("expected a Rate Limited error rather than a timeout, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail(), "The provider's rate limiter") || !strings.Contains(diags[0].Detail(), "Retry in 45s") {
+		t.Fatalf("unexpected detail: %q", diags[0].Detail())
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// Every diagnostic added by this package goes through these helpers so it carries its catalog code
+// and registered secrets echoed back in response bodies or error strings never reach Terraform output.
+
+// addError adds an error diagnostic formatted by the default catalog with registered secrets redacted
+func addError(diags *diag.Diagnostics, code Code, summary string, detail string) {
+	summary, detail = DefaultCatalog.Format(code, summary, detail)
+	diags.AddError(redact.String(summary), redact.String(detail))
+}
+
+// addWarning adds a warning diagnostic formatted by the default catalog with registered secrets redacted
+func addWarning(diags *diag.Diagnostics, code Code, summary string, detail string) {
+	summary, detail = DefaultCatalog.Format(code, summary, detail)
+	diags.AddWarning(redact.String(summary), redact.String(detail))
+}
+
+// addAttributeError adds an attribute error diagnostic formatted by the default catalog with registered secrets redacted
+func addAttributeError(diags *diag.Diagnostics, attributePath path.Path, code Code, summary string, detail string) {
+	summary, detail = DefaultCatalog.Format(code, summary, detail)
+	diags.AddAttributeError(attributePath, redact.String(summary), redact.String(detail))
+}
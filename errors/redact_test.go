@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+const testSecret = "nx-p4ssw0rd"
+
+func registerTestSecret(t *testing.T) {
+	t.Helper()
+	redact.Add(testSecret)
+	t.Cleanup(redact.Default.Reset)
+}
+
+func assertNoSecret(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+	if len(diags) == 0 {
+		t.Fatal("expected diagnostics")
+	}
+	for _, d := range diags {
+		if strings.Contains(d.Summary(), testSecret) || strings.Contains(d.Detail(), testSecret) {
+			t.Fatalf("diagnostic %q leaked the secret: %s", d.Summary(), d.Detail())
+		}
+	}
+}
+
+// TestRedaction_Diagnostics tests that registered secrets echoed by the server never reach diagnostics
+func TestRedaction_Diagnostics(t *testing.T) {
+	registerTestSecret(t)
+	body := `{"message":"invalid password nx-p4ssw0rd"}`
+	err := errors.New("auth failed for nx-p4ssw0rd")
+
+	tests := map[string]func(diags *diag.Diagnostics){
+		"AddAPIErrorDiagnostic": func(diags *diag.Diagnostics) {
+			AddAPIErrorDiagnostic(diags, "creating", "user", testAPIResponse(http.StatusBadRequest, body), err)
+		},
+		"AddAPIErrorDiagnostic with ResponseError": func(diags *diag.Diagnostics) {
+			AddAPIErrorDiagnostic(diags, "creating", "user", nil, NewResponseError(testAPIResponse(http.StatusBadRequest, body), err))
+		},
+		"HandleAPIError": func(diags *diag.Diagnostics) {
+			HandleAPIError("Error creating user", &err, testAPIResponse(http.StatusInternalServerError, body), diags)
+		},
+		"HandleAPIError without response": func(diags *diag.Diagnostics) {
+			HandleAPIError("Error creating user", &err, nil, diags)
+		},
+		"HandleAPIWarning": func(diags *diag.Diagnostics) {
+			HandleAPIWarning("Problem reading user", &err, testAPIResponse(http.StatusOK, body), diags)
+		},
+		"DiagnoseResponse": func(diags *diag.Diagnostics) {
+			DiagnoseResponse(diags, "creating", "user", "admin", testAPIResponse(http.StatusUnauthorized, body), err)
+		},
+		"AddFieldErrorDiagnostics": func(diags *diag.Diagnostics) {
+			AddFieldErrorDiagnostics(diags, "Error creating user", []byte(`[{"id":"password","message":"nx-p4ssw0rd is too weak"}]`), FieldPaths{})
+		},
+		"AddServerErrorDiagnostic": func(diags *diag.Diagnostics) {
+			AddServerErrorDiagnostic(diags, body, http.StatusBadGateway)
+		},
+	}
+
+	for name, add := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			add(&diags)
+			assertNoSecret(t, diags)
+		})
+	}
+}
+
+// TestRedaction_Errors tests that strings returned by the package are redacted
+func TestRedaction_Errors(t *testing.T) {
+	registerTestSecret(t)
+
+	apiErr := NewResponseError(testAPIResponse(http.StatusBadRequest, "bad password nx-p4ssw0rd"), nil)
+	if strings.Contains(apiErr.Error(), testSecret) {
+		t.Fatalf("ResponseError.Error() leaked the secret: %s", apiErr.Error())
+	}
+	if message := ParseAPIError(testAPIResponse(http.StatusBadRequest, "bad password nx-p4ssw0rd")); strings.Contains(message, testSecret) {
+		t.Fatalf("ParseAPIError() leaked the secret: %s", message)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// retryCountKey is the context key under which a retrying transport records how often a request was retried
+type retryCountKey struct{}
+
+// RetryError is returned by a retrying transport when it gives up on a request that failed without a response
+type RetryError struct {
+	Retries int
+	Err     error
+}
+
+// Error implements the error interface
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d retries: %v", e.Retries, e.Err)
+}
+
+// Unwrap returns the last error returned by the underlying transport
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// SetRetryCount records the number of retries in the context of the response's request.
+// The response headers are left as the server sent them.
+func SetRetryCount(httpResponse *http.Response, retries int) {
+	if httpResponse == nil || retries <= 0 {
+		return
+	}
+	req := httpResponse.Request
+	if req == nil {
+		req = &http.Request{}
+	}
+	httpResponse.Request = req.WithContext(context.WithValue(req.Context(), retryCountKey{}, retries))
+}
+
+// RetryCount returns the number of retries recorded on the error or the response, or 0 if none
+func RetryCount(err error, httpResponse *http.Response) int {
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		return retryErr.Retries
+	}
+	if httpResponse != nil && httpResponse.Request != nil {
+		if retries, ok := httpResponse.Request.Context().Value(retryCountKey{}).(int); ok {
+			return retries
+		}
+	}
+	return 0
+}
+
+// withRetryCount appends the retry count to a diagnostic detail when the request was retried
+func withRetryCount(detail string, err error, httpResponse *http.Response) string {
+	if retries := RetryCount(err, httpResponse); retries > 0 {
+		return fmt.Sprintf("%s (request retried %d time(s))", detail, retries)
+	}
+	return detail
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// TestRetryError tests the RetryError type
+func TestRetryError(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := fmt.Errorf("Get \"http://nexus\": %w", &RetryError{Retries: 2, Err: cause})
+
+	if !errors.Is(err, cause) {
+		t.Fatal("RetryError should unwrap to its cause")
+	}
+	if RetryCount(err, nil) != 2 {
+		t.Fatalf("Expected retry count 2, got %d", RetryCount(err, nil))
+	}
+}
+
+// TestRetryCount tests the SetRetryCount and RetryCount functions
+func TestRetryCount(t *testing.T) {
+	resp := &http.Response{}
+	if RetryCount(nil, resp) != 0 {
+		t.Fatal("Expected retry count 0 for a response without retries")
+	}
+
+	SetRetryCount(resp, 3)
+	if RetryCount(nil, resp) != 3 {
+		t.Fatalf("Expected retry count 3, got %d", RetryCount(nil, resp))
+	}
+	if len(resp.Header) != 0 {
+		t.Fatalf("SetRetryCount should not change the response headers, got %v", resp.Header)
+	}
+
+	if RetryCount(nil, nil) != 0 {
+		t.Fatal("Expected retry count 0 for a nil response")
+	}
+}
+
+// TestHandleAPIErrorWithRetries tests that HandleAPIError reports the retry count
+func TestHandleAPIErrorWithRetries(t *testing.T) {
+	diags := diag.Diagnostics{}
+	var err error = &RetryError{Retries: 4, Err: errors.New("unexpected EOF")}
+
+	HandleAPIError("Error reading user", &err, nil, &diags)
+
+	if !strings.Contains(diags.Errors()[0].Detail(), "retried 4 time(s)") {
+		t.Fatalf("Expected detail to include the retry count, got '%s'", diags.Errors()[0].Detail())
+	}
+}
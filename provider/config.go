@@ -0,0 +1,352 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/resource"
+)
+
+const (
+	// ProfileAttribute is the provider attribute naming the credentials file profile
+	ProfileAttribute = "profile"
+	// CredentialsFileAttribute is the provider attribute naming the credentials file
+	CredentialsFileAttribute = "credentials_file"
+	// ProfileEnvVar is the environment variable naming the credentials file profile
+	ProfileEnvVar = "SONATYPE_PROFILE"
+	// CredentialsFileEnvVar is the environment variable naming the credentials file
+	CredentialsFileEnvVar = "SONATYPE_CREDENTIALS_FILE"
+	// DefaultProfile is the profile used when none is configured
+	DefaultProfile = "default"
+)
+
+// Source identifies where a resolved setting value came from
+type Source int
+
+const (
+	// SourceNone means no value was found
+	SourceNone Source = iota
+	// SourceConfig means the value was set in the provider block
+	SourceConfig
+	// SourceEnvironment means the value was read from an environment variable
+	SourceEnvironment
+	// SourceProfile means the value was read from a credentials file profile
+	SourceProfile
+	// SourceDefault means the setting's default value was used
+	SourceDefault
+)
+
+// String returns a human readable name for the source
+func (s Source) String() string {
+	switch s {
+	case SourceConfig:
+		return "provider configuration"
+	case SourceEnvironment:
+		return "environment"
+	case SourceProfile:
+		return "credentials profile"
+	case SourceDefault:
+		return "default"
+	default:
+		return "none"
+	}
+}
+
+// Setting describes a provider setting and where it may be resolved from
+type Setting struct {
+	// Name is the provider schema attribute name
+	Name string
+	// EnvVars are checked in order when the attribute is not set
+	EnvVars []string
+	// ProfileKey is the key in the credentials file profile, defaulting to Name
+	ProfileKey string
+	// Required settings add an error diagnostic when no source provides a value
+	Required bool
+	// Sensitive settings never have their value logged and are registered with the redaction registry
+	Sensitive bool
+	// Default is used when no source provides a value
+	Default string
+}
+
+// profileKey returns the credentials file key for the setting
+func (s Setting) profileKey() string {
+	if s.ProfileKey != "" {
+		return s.ProfileKey
+	}
+	return s.Name
+}
+
+// Value is a resolved setting value together with its source
+type Value struct {
+	Value  string
+	Source Source
+	// Origin names the environment variable or credentials file the value came from
+	Origin string
+}
+
+// Config holds resolved provider settings
+type Config struct {
+	values map[string]Value
+}
+
+// Lookup returns the resolved value for a setting
+func (c *Config) Lookup(name string) (Value, bool) {
+	if c == nil {
+		return Value{}, false
+	}
+	value, ok := c.values[name]
+	return value, ok
+}
+
+// Get returns the resolved value for a setting, or an empty string
+func (c *Config) Get(name string) string {
+	value, _ := c.Lookup(name)
+	return value.Value
+}
+
+// Source returns where the value for a setting came from
+func (c *Config) Source(name string) Source {
+	value, _ := c.Lookup(name)
+	return value.Source
+}
+
+// Bool returns the resolved value for a setting parsed as a boolean, false when unset
+func (c *Config) Bool(name string) (bool, error) {
+	value := c.Get(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q from %s", name, value, c.Source(name))
+	}
+	return parsed, nil
+}
+
+// Int64 returns the resolved value for a setting parsed as an integer, 0 when unset
+func (c *Config) Int64(name string) (int64, error) {
+	value := c.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q from %s", name, value, c.Source(name))
+	}
+	return parsed, nil
+}
+
+// Float64 returns the resolved value for a setting parsed as a number, 0 when unset
+func (c *Config) Float64(name string) (float64, error) {
+	value := c.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, got %q from %s", name, value, c.Source(name))
+	}
+	return parsed, nil
+}
+
+// Loader resolves provider settings from the provider block, the environment and a credentials file profile
+type Loader struct {
+	settings []Setting
+	// LookupEnv reads environment variables, defaulting to os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+	// DefaultCredentialsFile is read when no credentials file is configured; a missing file is ignored
+	DefaultCredentialsFile string
+}
+
+// NewLoader creates a loader for the given settings
+func NewLoader(settings ...Setting) *Loader {
+	loader := &Loader{
+		settings:  settings,
+		LookupEnv: os.LookupEnv,
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		loader.DefaultCredentialsFile = filepath.Join(home, ".sonatype", "credentials")
+	}
+	return loader
+}
+
+// Load resolves every setting from the provider block, then the environment, then the credentials file profile
+func (l *Loader) Load(ctx context.Context, config tfsdk.Config) (*Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := map[string]string{}
+	for _, name := range l.attributeNames() {
+		value, ok := configValue(ctx, config, name, &diags)
+		if ok {
+			configured[name] = value
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	profile, profileDiags := l.loadProfile(configured)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := &Config{values: make(map[string]Value, len(l.settings))}
+	for _, setting := range l.settings {
+		value := l.resolve(setting, configured, profile)
+		if value.Source == SourceNone && setting.Required {
+			diags.AddAttributeError(
+				path.Root(setting.Name),
+				fmt.Sprintf("Missing %s Configuration", setting.Name),
+				missingDetail(setting, profile),
+			)
+			continue
+		}
+		result.values[setting.Name] = value
+		if setting.Sensitive {
+			redact.Add(value.Value)
+		}
+
+		fields := map[string]interface{}{"setting": setting.Name, "source": value.Source.String()}
+		if value.Origin != "" {
+			fields["origin"] = value.Origin
+		}
+		if !setting.Sensitive {
+			fields["value"] = value.Value
+		}
+		tflog.Debug(ctx, "Resolved provider setting", fields)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return result, diags
+}
+
+// NewResourceConfig builds the configuration shared with resources, using the named setting as the base URL
+func NewResourceConfig[C any, A any](config *Config, urlSetting string, client C, auth A) *resource.TypedBaseResourceConfig[C, A] {
+	return &resource.TypedBaseResourceConfig[C, A]{
+		Auth:    auth,
+		BaseURL: strings.TrimRight(config.Get(urlSetting), "/"),
+		Client:  client,
+	}
+}
+
+// attributeNames returns every provider attribute the loader reads
+func (l *Loader) attributeNames() []string {
+	names := []string{ProfileAttribute, CredentialsFileAttribute}
+	for _, setting := range l.settings {
+		names = append(names, setting.Name)
+	}
+	return names
+}
+
+// resolve finds the value of a single setting
+func (l *Loader) resolve(setting Setting, configured map[string]string, profile *credentialsProfile) Value {
+	if value, ok := configured[setting.Name]; ok {
+		return Value{Value: value, Source: SourceConfig}
+	}
+	for _, envVar := range setting.EnvVars {
+		if value, ok := l.lookupEnv(envVar); ok && value != "" {
+			return Value{Value: value, Source: SourceEnvironment, Origin: envVar}
+		}
+	}
+	if profile != nil {
+		if value, ok := profile.values[setting.profileKey()]; ok && value != "" {
+			return Value{Value: value, Source: SourceProfile, Origin: profile.origin()}
+		}
+	}
+	if setting.Default != "" {
+		return Value{Value: setting.Default, Source: SourceDefault}
+	}
+	return Value{}
+}
+
+// lookupEnv reads an environment variable using the configured lookup
+func (l *Loader) lookupEnv(key string) (string, bool) {
+	if l.LookupEnv == nil {
+		return os.LookupEnv(key)
+	}
+	return l.LookupEnv(key)
+}
+
+// configValue reads an attribute from the provider block as a string, reporting unknown values
+func configValue(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) (string, bool) {
+	if config.Schema == nil {
+		return "", false
+	}
+	if _, ok := config.Schema.GetAttributes()[name]; !ok {
+		return "", false
+	}
+
+	var value attr.Value
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+	if value == nil || value.IsNull() {
+		return "", false
+	}
+	if value.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root(name),
+			fmt.Sprintf("Unknown %s Configuration", name),
+			fmt.Sprintf("The provider cannot be configured because %q is unknown. Set it to a known value, or remove it and use the environment or a credentials profile instead.", name),
+		)
+		return "", false
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString(), true
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool()), true
+	case types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10), true
+	case types.Int32:
+		return strconv.FormatInt(int64(v.ValueInt32()), 10), true
+	case types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64), true
+	default:
+		return value.String(), true
+	}
+}
+
+// missingDetail describes every place a missing setting could have been provided
+func missingDetail(setting Setting, profile *credentialsProfile) string {
+	places := []string{fmt.Sprintf("the %q attribute in the provider block", setting.Name)}
+	for _, envVar := range setting.EnvVars {
+		places = append(places, fmt.Sprintf("the %s environment variable", envVar))
+	}
+	if profile != nil {
+		places = append(places, fmt.Sprintf("the %q key in %s", setting.profileKey(), profile.origin()))
+	} else {
+		places = append(places, fmt.Sprintf("the %q key in a credentials file profile", setting.profileKey()))
+	}
+	return fmt.Sprintf("The provider requires a value for %q. Set it using %s.", setting.Name, strings.Join(places, ", or "))
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+func testProviderSchema() providerschema.Schema {
+	return providerschema.Schema{Attributes: map[string]providerschema.Attribute{
+		URLSetting:                providerschema.StringAttribute{Optional: true},
+		UsernameSetting:           providerschema.StringAttribute{Optional: true},
+		PasswordSetting:           providerschema.StringAttribute{Optional: true, Sensitive: true},
+		InsecureSkipVerifySetting: providerschema.BoolAttribute{Optional: true},
+		ProfileAttribute:          providerschema.StringAttribute{Optional: true},
+		CredentialsFileAttribute:  providerschema.StringAttribute{Optional: true},
+	}}
+}
+
+func testProviderConfig(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	s := testProviderSchema()
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	raw := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		raw[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		raw[name] = value
+	}
+
+	return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objectType, raw)}
+}
+
+func testLoader(env map[string]string, credentialsFile string) *Loader {
+	loader := NewLoader(NexusRepositorySettings()...)
+	loader.LookupEnv = func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	loader.DefaultCredentialsFile = credentialsFile
+	return loader
+}
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	file := writeCredentialsFile(t, `
+# shared credentials
+[default]
+url = https://profile.example.com
+username = profile-user
+password = "profile-secret"
+`)
+	loader := testLoader(map[string]string{"NXRM_USERNAME": "env-user"}, file)
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		URLSetting: tftypes.NewValue(tftypes.String, "https://hcl.example.com/"),
+	})
+
+	cfg, diags := loader.Load(context.Background(), config)
+	if diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+
+	expected := map[string]struct {
+		value  string
+		source Source
+	}{
+		URLSetting:                {"https://hcl.example.com/", SourceConfig},
+		UsernameSetting:           {"env-user", SourceEnvironment},
+		PasswordSetting:           {"profile-secret", SourceProfile},
+		InsecureSkipVerifySetting: {"false", SourceDefault},
+	}
+	for name, want := range expected {
+		if cfg.Get(name) != want.value || cfg.Source(name) != want.source {
+			t.Fatalf("%s = %q from %s, expected %q from %s", name, cfg.Get(name), cfg.Source(name), want.value, want.source)
+		}
+	}
+
+	value, _ := cfg.Lookup(UsernameSetting)
+	if value.Origin != "NXRM_USERNAME" {
+		t.Fatalf("Origin = %q, expected 'NXRM_USERNAME'", value.Origin)
+	}
+
+	resourceConfig := NewResourceConfig(cfg, URLSetting, "client", "auth")
+	if resourceConfig.GetBaseURL() != "https://hcl.example.com" || resourceConfig.GetClient() != "client" {
+		t.Fatalf("NewResourceConfig() = %+v, unexpected values", resourceConfig)
+	}
+}
+
+func TestLoad_BoolFromConfig(t *testing.T) {
+	loader := testLoader(map[string]string{
+		"NXRM_SERVER_URL": "https://legacy.example.com",
+		"NXRM_USERNAME":   "admin",
+		"NXRM_PASSWORD":   "secret",
+	}, "")
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		InsecureSkipVerifySetting: tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	cfg, diags := loader.Load(context.Background(), config)
+	if diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+
+	insecure, err := cfg.Bool(InsecureSkipVerifySetting)
+	if err != nil || !insecure {
+		t.Fatalf("Bool() = %v, %v, expected true", insecure, err)
+	}
+	if cfg.Get(URLSetting) != "https://legacy.example.com" {
+		t.Fatalf("url = %q, expected the NXRM_SERVER_URL fallback", cfg.Get(URLSetting))
+	}
+}
+
+func TestLoad_NamedProfile(t *testing.T) {
+	file := writeCredentialsFile(t, `
+[default]
+url = https://default.example.com
+
+[staging]
+url = https://staging.example.com
+username = stage
+password = stage-secret
+`)
+	loader := testLoader(map[string]string{ProfileEnvVar: "staging", CredentialsFileEnvVar: file}, "")
+
+	cfg, diags := loader.Load(context.Background(), testProviderConfig(t, nil))
+	if diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+	if cfg.Get(URLSetting) != "https://staging.example.com" {
+		t.Fatalf("url = %q, expected the staging profile value", cfg.Get(URLSetting))
+	}
+}
+
+func TestLoad_MissingRequired(t *testing.T) {
+	loader := testLoader(map[string]string{"NXRM_URL": "https://env.example.com"}, filepath.Join(t.TempDir(), "missing"))
+
+	_, diags := loader.Load(context.Background(), testProviderConfig(t, nil))
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected username and password errors, got: %v", diags)
+	}
+	detail := diags.Errors()[0].Detail()
+	if !strings.Contains(detail, "NXRM_") || !strings.Contains(detail, "provider block") {
+		t.Fatalf("detail %q should list every source", detail)
+	}
+}
+
+func TestLoad_UnknownProfile(t *testing.T) {
+	file := writeCredentialsFile(t, "[default]\nurl = https://default.example.com\n")
+	loader := testLoader(nil, file)
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		ProfileAttribute: tftypes.NewValue(tftypes.String, "prod"),
+	})
+
+	_, diags := loader.Load(context.Background(), config)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unknown Credentials Profile" {
+		t.Fatalf("expected 'Unknown Credentials Profile', got: %v", diags)
+	}
+}
+
+func TestLoad_MissingExplicitCredentialsFile(t *testing.T) {
+	loader := testLoader(nil, "")
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		CredentialsFileAttribute: tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+	})
+
+	_, diags := loader.Load(context.Background(), config)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Read Credentials File" {
+		t.Fatalf("expected 'Unable to Read Credentials File', got: %v", diags)
+	}
+	if got := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root(CredentialsFileAttribute)) {
+		t.Fatalf("error path = %s, expected %s", got, CredentialsFileAttribute)
+	}
+}
+
+func TestLoad_ExplicitProfileWithMissingDefaultFile(t *testing.T) {
+	loader := testLoader(nil, filepath.Join(t.TempDir(), "missing"))
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		ProfileAttribute: tftypes.NewValue(tftypes.String, "prod"),
+	})
+
+	_, diags := loader.Load(context.Background(), config)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Read Credentials File" {
+		t.Fatalf("expected 'Unable to Read Credentials File', got: %v", diags)
+	}
+	if got := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root(ProfileAttribute)) {
+		t.Fatalf("error path = %s, expected %s", got, ProfileAttribute)
+	}
+}
+
+func TestLoad_UnknownValue(t *testing.T) {
+	loader := testLoader(nil, "")
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		URLSetting: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	_, diags := loader.Load(context.Background(), config)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unknown url Configuration" {
+		t.Fatalf("expected 'Unknown url Configuration', got: %v", diags)
+	}
+}
+
+func TestReadCredentialsFile_Invalid(t *testing.T) {
+	file := writeCredentialsFile(t, "url = https://example.com\n")
+
+	if _, err := readCredentialsFile(file); err == nil {
+		t.Fatal("readCredentialsFile should reject keys outside a profile")
+	}
+}
+
+func TestLoad_RegistersSensitiveValues(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	loader := testLoader(map[string]string{"NXRM_USERNAME": "admin-user", "NXRM_PASSWORD": "env-secret"}, "")
+	config := testProviderConfig(t, map[string]tftypes.Value{
+		URLSetting: tftypes.NewValue(tftypes.String, "https://hcl.example.com/"),
+	})
+
+	if _, diags := loader.Load(context.Background(), config); diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+	if actual := redact.String("admin-user:env-secret"); actual != "admin-user:***" {
+		t.Fatalf("redact.String() = %q, expected only the password to be registered", actual)
+	}
+}
+
+func TestLoad_RateLimitSettings(t *testing.T) {
+	loader := NewLoader(append(NexusRepositorySettings(), RateLimitSettings("NXRM")...)...)
+	loader.LookupEnv = func(key string) (string, bool) {
+		value, ok := map[string]string{
+			"NXRM_URL":                 "https://nexus.example.com",
+			"NXRM_USERNAME":            "admin",
+			"NXRM_PASSWORD":            "secret",
+			"NXRM_REQUESTS_PER_SECOND": "2.5",
+			"NXRM_REQUEST_BURST":       "5",
+		}[key]
+		return value, ok
+	}
+	loader.DefaultCredentialsFile = ""
+
+	cfg, diags := loader.Load(context.Background(), testProviderConfig(t, nil))
+	if diags.HasError() {
+		t.Fatalf("Load returned unexpected errors: %v", diags)
+	}
+	if rate, err := cfg.Float64(RequestsPerSecondSetting); err != nil || rate != 2.5 {
+		t.Fatalf("Float64() = %v, %v, expected 2.5", rate, err)
+	}
+	if burst, err := cfg.Int64(RequestBurstSetting); err != nil || burst != 5 {
+		t.Fatalf("Int64() = %v, %v, expected 5", burst, err)
+	}
+	if _, err := (&Config{values: map[string]Value{RequestBurstSetting: {Value: "many"}}}).Int64(RequestBurstSetting); err == nil {
+		t.Fatal("Int64() should reject a non-integer value")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// credentialsProfile is a single profile read from a credentials file
+type credentialsProfile struct {
+	file   string
+	name   string
+	values map[string]string
+}
+
+// origin describes the profile for diagnostics
+func (p *credentialsProfile) origin() string {
+	return fmt.Sprintf("profile %q of %s", p.name, p.file)
+}
+
+// loadProfile reads the configured profile, returning nil when no credentials file applies
+func (l *Loader) loadProfile(configured map[string]string) (*credentialsProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	name, explicitProfile := configured[ProfileAttribute]
+	if !explicitProfile {
+		name, explicitProfile = l.lookupEnv(ProfileEnvVar)
+	}
+	if name == "" {
+		name, explicitProfile = DefaultProfile, false
+	}
+
+	file, explicitFile := configured[CredentialsFileAttribute]
+	if !explicitFile {
+		file, explicitFile = l.lookupEnv(CredentialsFileEnvVar)
+	}
+	if file == "" {
+		file, explicitFile = l.DefaultCredentialsFile, false
+	}
+	if file == "" {
+		return nil, diags
+	}
+
+	profiles, err := readCredentialsFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicitFile && !explicitProfile {
+			return nil, diags
+		}
+		// Report the error on the setting that made the file matter
+		attribute := CredentialsFileAttribute
+		if !explicitFile {
+			attribute = ProfileAttribute
+		}
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Unable to Read Credentials File",
+			fmt.Sprintf("The credentials file %s could not be read: %v", file, err),
+		)
+		return nil, diags
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		if explicitProfile {
+			diags.AddAttributeError(
+				path.Root(ProfileAttribute),
+				"Unknown Credentials Profile",
+				fmt.Sprintf("The profile %q was not found in %s.", name, file),
+			)
+		}
+		return nil, diags
+	}
+
+	return &credentialsProfile{file: file, name: name, values: values}, diags
+}
+
+// readCredentialsFile parses an INI style credentials file into profiles
+func readCredentialsFile(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || current == nil {
+			return nil, fmt.Errorf("line %d: expected a [profile] header or key = value", lineNumber)
+		}
+		current[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+
+	return profiles, scanner.Err()
+}
+
+// unquote removes matching surrounding quotes from a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+const (
+	// URLSetting is the attribute holding the server URL
+	URLSetting = "url"
+	// UsernameSetting is the attribute holding the username
+	UsernameSetting = "username"
+	// PasswordSetting is the attribute holding the password
+	PasswordSetting = "password"
+	// InsecureSkipVerifySetting is the attribute disabling TLS certificate verification
+	InsecureSkipVerifySetting = "insecure_skip_verify"
+	// CACertFileSetting is the attribute holding the path to a PEM CA bundle used to verify the server
+	CACertFileSetting = "ca_cert_file"
+	// RequestsPerSecondSetting is the attribute holding the client-side request rate limit
+	RequestsPerSecondSetting = "requests_per_second"
+	// RequestBurstSetting is the attribute holding the number of requests that may be sent at once
+	RequestBurstSetting = "request_burst"
+)
+
+// NexusRepositorySettings returns the standard settings for Sonatype Nexus Repository providers
+func NexusRepositorySettings() []Setting {
+	return serverSettings("NXRM")
+}
+
+// IQServerSettings returns the standard settings for Sonatype IQ Server providers
+func IQServerSettings() []Setting {
+	return serverSettings("IQ")
+}
+
+// serverSettings returns url, username, password, insecure_skip_verify and ca_cert_file settings read from PREFIX_* variables
+func serverSettings(prefix string) []Setting {
+	return []Setting{
+		{Name: URLSetting, EnvVars: []string{prefix + "_URL", prefix + "_SERVER_URL"}, Required: true},
+		{Name: UsernameSetting, EnvVars: []string{prefix + "_USERNAME", prefix + "_SERVER_USERNAME"}, Required: true},
+		{Name: PasswordSetting, EnvVars: []string{prefix + "_PASSWORD", prefix + "_SERVER_PASSWORD"}, Required: true, Sensitive: true},
+		{Name: InsecureSkipVerifySetting, EnvVars: []string{prefix + "_INSECURE_SKIP_VERIFY"}, Default: "false"},
+		{Name: CACertFileSetting, EnvVars: []string{prefix + "_CA_CERT_FILE"}},
+	}
+}
+
+// RateLimitSettings returns optional requests_per_second and request_burst settings read from PREFIX_* variables.
+// Append them to the server settings of providers that expose client-side rate limiting.
+func RateLimitSettings(prefix string) []Setting {
+	return []Setting{
+		{Name: RequestsPerSecondSetting, EnvVars: []string{prefix + "_REQUESTS_PER_SECOND"}},
+		{Name: RequestBurstSetting, EnvVars: []string{prefix + "_REQUEST_BURST"}},
+	}
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package redact keeps a registry of secret values known to the provider and scrubs them
+// from diagnostics, errors and log output.
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Mask replaces each secret in redacted text. It matches the mask used by tflog.
+const Mask = "***"
+
+// MinSecretLength is the length below which values are not registered, so short values do not mask ordinary words
+var MinSecretLength = 4
+
+// Registry holds secret values that must never appear in diagnostics, errors or logs
+type Registry struct {
+	mu       sync.RWMutex
+	secrets  map[string]struct{}
+	replacer *strings.Replacer
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{secrets: map[string]struct{}{}}
+}
+
+// Default is the registry fed by the sensitive schema builders and the provider configuration loader
+var Default = NewRegistry()
+
+// Add registers secrets together with their URL and JSON escaped forms
+func (r *Registry) Add(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, secret := range secrets {
+		if len(secret) < MinSecretLength {
+			continue
+		}
+		for _, form := range escapedForms(secret) {
+			if _, ok := r.secrets[form]; !ok {
+				r.secrets[form] = struct{}{}
+				r.replacer = nil
+			}
+		}
+	}
+}
+
+// Reset removes every registered secret
+func (r *Registry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets = map[string]struct{}{}
+	r.replacer = nil
+}
+
+// Secrets returns the registered secrets, longest first
+func (r *Registry) Secrets() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sorted()
+}
+
+// String replaces every registered secret in text with Mask
+func (r *Registry) String(text string) string {
+	if text == "" {
+		return text
+	}
+	if replacer := r.getReplacer(); replacer != nil {
+		return replacer.Replace(text)
+	}
+	return text
+}
+
+// Bytes replaces every registered secret in data with Mask
+func (r *Registry) Bytes(data []byte) []byte {
+	if len(data) == 0 {
+		return data
+	}
+	return []byte(r.String(string(data)))
+}
+
+// Error returns an error whose message has every registered secret replaced with Mask.
+// The original error stays available to errors.Is and errors.As through Unwrap.
+func (r *Registry) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	if redacted := r.String(message); redacted != message {
+		return &redactedError{message: redacted, err: err}
+	}
+	return err
+}
+
+// Diagnostics returns a copy of diags with every registered secret in summaries and details replaced with Mask
+func (r *Registry) Diagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	if len(diags) == 0 {
+		return diags
+	}
+	redacted := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		summary, detail := r.String(d.Summary()), r.String(d.Detail())
+		if summary == d.Summary() && detail == d.Detail() {
+			redacted = append(redacted, d)
+			continue
+		}
+		withPath, hasPath := d.(diag.DiagnosticWithPath)
+		switch {
+		case hasPath && d.Severity() == diag.SeverityWarning:
+			redacted = append(redacted, diag.NewAttributeWarningDiagnostic(withPath.Path(), summary, detail))
+		case hasPath:
+			redacted = append(redacted, diag.NewAttributeErrorDiagnostic(withPath.Path(), summary, detail))
+		case d.Severity() == diag.SeverityWarning:
+			redacted = append(redacted, diag.NewWarningDiagnostic(summary, detail))
+		default:
+			redacted = append(redacted, diag.NewErrorDiagnostic(summary, detail))
+		}
+	}
+	return redacted
+}
+
+// Context returns a context whose tflog messages and field values have every registered secret masked
+func (r *Registry) Context(ctx context.Context) context.Context {
+	secrets := r.Secrets()
+	if len(secrets) == 0 {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, secrets...)
+}
+
+// getReplacer returns the replacer for the registered secrets, building it when secrets have changed
+func (r *Registry) getReplacer() *strings.Replacer {
+	r.mu.RLock()
+	replacer, empty := r.replacer, len(r.secrets) == 0
+	r.mu.RUnlock()
+	if replacer != nil || empty {
+		return replacer
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replacer == nil && len(r.secrets) > 0 {
+		pairs := make([]string, 0, len(r.secrets)*2)
+		for _, secret := range r.sorted() {
+			pairs = append(pairs, secret, Mask)
+		}
+		r.replacer = strings.NewReplacer(pairs...)
+	}
+	return r.replacer
+}
+
+// sorted returns the secrets longest first, so a secret containing another is replaced whole.
+// The caller must hold the lock.
+func (r *Registry) sorted() []string {
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+	return secrets
+}
+
+// escapedForms returns the secret as written and as it appears in URLs and JSON bodies
+func escapedForms(secret string) []string {
+	forms := []string{secret}
+	for _, form := range []string{url.QueryEscape(secret), url.PathEscape(secret), jsonEscape(secret)} {
+		if form != secret {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// jsonEscape returns the secret as it appears inside a JSON string
+func jsonEscape(secret string) string {
+	encoded, err := json.Marshal(secret)
+	if err != nil {
+		return secret
+	}
+	return string(encoded[1 : len(encoded)-1])
+}
+
+// redactedError is an error whose message has had secrets removed
+type redactedError struct {
+	message string
+	err     error
+}
+
+// Error implements the error interface
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Add registers secrets with the default registry
+func Add(secrets ...string) {
+	Default.Add(secrets...)
+}
+
+// String replaces every secret in the default registry with Mask
+func String(text string) string {
+	return Default.String(text)
+}
+
+// Bytes replaces every secret in the default registry with Mask
+func Bytes(data []byte) []byte {
+	return Default.Bytes(data)
+}
+
+// Error redacts the error message using the default registry
+func Error(err error) error {
+	return Default.Error(err)
+}
+
+// Diagnostics redacts diagnostics using the default registry
+func Diagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	return Default.Diagnostics(diags)
+}
+
+// Context masks the secrets in the default registry from tflog output
+func Context(ctx context.Context) context.Context {
+	return Default.Context(ctx)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redact
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// TestRegistry_String tests that secrets and their escaped forms are masked
+func TestRegistry_String(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add("s3cr3t/pass word", "abc")
+
+	tests := map[string]string{
+		"password s3cr3t/pass word rejected":      "password *** rejected",
+		"https://host/?p=s3cr3t%2Fpass+word":      "https://host/?p=***",
+		"https://host/s3cr3t%2Fpass%20word/login": "https://host/***/login",
+		"short value abc is kept":                 "short value abc is kept",
+	}
+	for input, expected := range tests {
+		if actual := registry.String(input); actual != expected {
+			t.Errorf("String(%q) = %q, expected %q", input, actual, expected)
+		}
+	}
+}
+
+// TestRegistry_LongestFirst tests that a secret containing another secret is masked whole
+func TestRegistry_LongestFirst(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add("token", "token-with-suffix")
+
+	if actual := registry.String("got token-with-suffix"); actual != "got ***" {
+		t.Fatalf("String() = %q, expected 'got ***'", actual)
+	}
+}
+
+// TestRegistry_JSONEscaped tests that secrets are masked inside JSON encoded bodies
+func TestRegistry_JSONEscaped(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add(`pa"ss\word`)
+
+	body := registry.Bytes([]byte(`{"password":"pa\"ss\\word"}`))
+	if string(body) != `{"password":"***"}` {
+		t.Fatalf("Bytes() = %s, expected the password to be masked", body)
+	}
+}
+
+// TestRegistry_Error tests that error messages are redacted and the chain is kept
+func TestRegistry_Error(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add("hunter22")
+
+	err := registry.Error(&fs.PathError{Op: "open", Path: "/tmp/hunter22", Err: fs.ErrNotExist})
+	if strings.Contains(err.Error(), "hunter22") {
+		t.Fatalf("Error() = %q, expected the secret to be masked", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal("expected the redacted error to wrap the original")
+	}
+
+	plain := errors.New("nothing secret")
+	if registry.Error(plain) != plain {
+		t.Fatal("expected an error without secrets to be returned unchanged")
+	}
+}
+
+// TestRegistry_Diagnostics tests that summaries and details are redacted and paths and severities kept
+func TestRegistry_Diagnostics(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add("hunter22")
+
+	diags := diag.Diagnostics{}
+	diags.AddError("Login hunter22", "bad password hunter22")
+	diags.AddAttributeWarning(path.Root("password"), "Weak", "hunter22 is weak")
+	diags.AddWarning("Unrelated", "no secrets here")
+
+	redacted := registry.Diagnostics(diags)
+	if len(redacted) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(redacted))
+	}
+	for _, d := range redacted {
+		if strings.Contains(d.Summary()+d.Detail(), "hunter22") {
+			t.Fatalf("diagnostic %q still contains the secret", d.Summary())
+		}
+	}
+	withPath, ok := redacted[1].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("password")) || redacted[1].Severity() != diag.SeverityWarning {
+		t.Fatalf("expected the attribute warning to keep its path and severity, got %v", redacted[1])
+	}
+	if redacted[0].Severity() != diag.SeverityError {
+		t.Fatal("expected the error to keep its severity")
+	}
+}
+
+// TestRegistry_Context tests that an empty registry leaves the context unchanged
+func TestRegistry_Context(t *testing.T) {
+	ctx := context.Background()
+	if NewRegistry().Context(ctx) != ctx {
+		t.Fatal("expected an empty registry to return the context unchanged")
+	}
+}
+
+// TestRegistry_Reset tests that Reset removes every secret
+func TestRegistry_Reset(t *testing.T) {
+	registry := NewRegistry()
+	registry.Add("hunter22")
+	registry.Reset()
+
+	if len(registry.Secrets()) != 0 || registry.String("hunter22") != "hunter22" {
+		t.Fatal("expected Reset to remove every secret")
+	}
+}
@@ -19,86 +19,224 @@ package resource
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/server"
 )
 
-// BaseProvider contains common provider configuration
-type BaseProvider interface {
-	GetAuth() interface{}
+// TypedBaseProvider contains common provider configuration with a typed client and auth
+type TypedBaseProvider[C any, A any] interface {
+	GetAuth() A
 	GetBaseURL() string
-	GetClient() interface{}
+	GetClient() C
 }
 
-// BaseResourceConfig holds common configuration for all resources
-type BaseResourceConfig struct {
-	Auth    interface{}
+// BaseProvider contains common provider configuration
+type BaseProvider = TypedBaseProvider[interface{}, interface{}]
+
+// ServerProvider is implemented by providers that report the version and edition of their server
+type ServerProvider interface {
+	GetServer() server.Source
+}
+
+// TypedBaseResourceConfig holds common configuration for all resources with a typed client and auth
+type TypedBaseResourceConfig[C any, A any] struct {
+	Auth    A
 	BaseURL string
-	Client  interface{}
+	Client  C
+	// Server reports the server version and edition used to check schema.ServerRequirement markers
+	Server server.Source
+	// Locks serializes writes to shared server objects; the plugin wide locks are used when nil
+	Locks *MutexKV
+}
+
+// BaseResourceConfig holds common configuration for all resources
+type BaseResourceConfig = TypedBaseResourceConfig[interface{}, interface{}]
+
+// TypedBaseResource provides common functionality for all Terraform resources.
+// C is the API client type and A the authentication type handed over by the provider.
+type TypedBaseResource[C any, A any] struct {
+	config *TypedBaseResourceConfig[C, A]
 }
 
 // BaseResource provides common functionality for all Terraform resources
-type BaseResource struct {
-	config *BaseResourceConfig
+type BaseResource = TypedBaseResource[interface{}, interface{}]
+
+// NewTypedBaseResource creates a new TypedBaseResource with the given configuration
+func NewTypedBaseResource[C any, A any](config *TypedBaseResourceConfig[C, A]) *TypedBaseResource[C, A] {
+	return &TypedBaseResource[C, A]{
+		config: config,
+	}
 }
 
 // NewBaseResource creates a new BaseResource with the given configuration
 func NewBaseResource(config *BaseResourceConfig) *BaseResource {
-	return &BaseResource{
-		config: config,
-	}
+	return NewTypedBaseResource(config)
 }
 
 // Configure sets up the resource with provider configuration
-func (r *BaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
+func (r *TypedBaseResource[C, A]) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if config := configFromProviderData[C, A](req.ProviderData, &resp.Diagnostics); config != nil {
+		r.config = config
+	}
+}
+
+// GetAuth returns the authentication configuration
+func (r *TypedBaseResource[C, A]) GetAuth() A {
+	return r.config.GetAuth()
+}
+
+// GetBaseURL returns the API base URL
+func (r *TypedBaseResource[C, A]) GetBaseURL() string {
+	return r.config.GetBaseURL()
+}
+
+// GetClient returns the API client
+func (r *TypedBaseResource[C, A]) GetClient() C {
+	return r.config.GetClient()
+}
+
+// GetServer returns the source of the server version and edition, or nil when the provider reports none
+func (r *TypedBaseResource[C, A]) GetServer() server.Source {
+	return r.config.GetServer()
+}
+
+// IsConfigured checks if the resource has been properly configured
+func (r *TypedBaseResource[C, A]) IsConfigured() bool {
+	return r.config.IsConfigured()
+}
+
+// GetAuth returns the authentication configuration, or the zero value for a nil config
+func (c *TypedBaseResourceConfig[C, A]) GetAuth() A {
+	if c == nil {
+		var zero A
+		return zero
 	}
+	return c.Auth
+}
 
-	provider, ok := req.ProviderData.(BaseProvider)
+// GetBaseURL returns the API base URL, or an empty string for a nil config
+func (c *TypedBaseResourceConfig[C, A]) GetBaseURL() string {
+	if c == nil {
+		return ""
+	}
+	return c.BaseURL
+}
+
+// GetClient returns the API client, or the zero value for a nil config
+func (c *TypedBaseResourceConfig[C, A]) GetClient() C {
+	if c == nil {
+		var zero C
+		return zero
+	}
+	return c.Client
+}
+
+// GetServer returns the source of the server version and edition, or nil for a nil config
+func (c *TypedBaseResourceConfig[C, A]) GetServer() server.Source {
+	if c == nil {
+		return nil
+	}
+	return c.Server
+}
+
+// IsConfigured checks if the config is set and holds a non-nil client
+func (c *TypedBaseResourceConfig[C, A]) IsConfigured() bool {
+	return c != nil && !isNil(c.Client)
+}
+
+// configFromProviderData extracts a typed resource configuration from the provider data.
+// Providers may implement either TypedBaseProvider[C, A] directly or the untyped BaseProvider,
+// in which case the client and auth values are checked against C and A. A single
+// "Unexpected Data Type" diagnostic is added on mismatch and nil is returned.
+func configFromProviderData[C any, A any](providerData interface{}, diags *diag.Diagnostics) *TypedBaseResourceConfig[C, A] {
+	if providerData == nil {
+		return nil
+	}
+
+	if provider, ok := providerData.(TypedBaseProvider[C, A]); ok {
+		return &TypedBaseResourceConfig[C, A]{
+			Auth:    provider.GetAuth(),
+			BaseURL: provider.GetBaseURL(),
+			Client:  provider.GetClient(),
+			Server:  serverOf(providerData),
+			Locks:   locksOf(providerData),
+		}
+	}
+
+	provider, ok := providerData.(BaseProvider)
 	if !ok {
-		resp.Diagnostics.AddError(
+		diags.AddError(
+			"Unexpected Data Type",
+			fmt.Sprintf("Expected BaseProvider, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil
+	}
+
+	client, clientOk := assertValue[C](provider.GetClient())
+	auth, authOk := assertValue[A](provider.GetAuth())
+	if !clientOk || !authOk {
+		diags.AddError(
 			"Unexpected Data Type",
-			fmt.Sprintf("Expected BaseProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf(
+				"Expected BaseProvider with client %s and auth %s, got client %T and auth %T from %T. Please report this issue to the provider developers.",
+				reflect.TypeFor[C](), reflect.TypeFor[A](), provider.GetClient(), provider.GetAuth(), providerData,
+			),
 		)
-		return
+		return nil
 	}
 
-	r.config = &BaseResourceConfig{
-		Auth:    provider.GetAuth(),
+	return &TypedBaseResourceConfig[C, A]{
+		Auth:    auth,
 		BaseURL: provider.GetBaseURL(),
-		Client:  provider.GetClient(),
+		Client:  client,
+		Server:  serverOf(providerData),
+		Locks:   locksOf(providerData),
 	}
 }
 
-// GetAuth returns the authentication configuration
-func (r *BaseResource) GetAuth() interface{} {
-	if r.config == nil {
-		return nil
+// serverOf returns the server source of providers implementing ServerProvider
+func serverOf(providerData interface{}) server.Source {
+	if provider, ok := providerData.(ServerProvider); ok {
+		return provider.GetServer()
 	}
-	return r.config.Auth
+	return nil
 }
 
-// GetBaseURL returns the API base URL
-func (r *BaseResource) GetBaseURL() string {
-	if r.config == nil {
-		return ""
+// locksOf returns the locks of providers implementing MutexKVProvider
+func locksOf(providerData interface{}) *MutexKV {
+	if provider, ok := providerData.(MutexKVProvider); ok {
+		return provider.GetLocks()
 	}
-	return r.config.BaseURL
+	return nil
 }
 
-// GetClient returns the API client
-func (r *BaseResource) GetClient() interface{} {
-	if r.config == nil {
-		return nil
+// assertValue converts v to T, treating a nil value as the zero value of T
+func assertValue[T any](v interface{}) (T, bool) {
+	if v == nil {
+		var zero T
+		return zero, true
 	}
-	return r.config.Client
+	t, ok := v.(T)
+	return t, ok
 }
 
-// IsConfigured checks if the resource has been properly configured
-func (r *BaseResource) IsConfigured() bool {
-	return r.config != nil && r.config.Client != nil
+// isNil reports whether v is nil, including typed nil pointers, maps, slices, channels and funcs
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
 }
 
 // HasError is a helper to check if diagnostics contain errors
@@ -106,13 +244,13 @@ func HasError(diags *diag.Diagnostics) bool {
 	return diags.HasError()
 }
 
-// AddErrorf adds a formatted error to diagnostics
+// AddErrorf adds a formatted error to diagnostics with registered secrets redacted
 func AddErrorf(diags *diag.Diagnostics, summary string, format string, args ...interface{}) {
-	diags.AddError(summary, fmt.Sprintf(format, args...))
+	diags.AddError(redact.String(summary), redact.String(fmt.Sprintf(format, args...)))
 }
 
-// AddErrorAndReturn adds an error to diagnostics and returns early
+// AddErrorAndReturn adds an error to diagnostics with registered secrets redacted and returns early
 func AddErrorAndReturn(diags *diag.Diagnostics, summary string, details string) bool {
-	diags.AddError(summary, details)
+	diags.AddError(redact.String(summary), redact.String(details))
 	return true
 }
@@ -22,6 +22,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
 )
 
 type MockProvider struct {
@@ -239,6 +241,21 @@ func TestAddErrorf(t *testing.T) {
 	}
 }
 
+func TestAddErrorf_Redacts(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+	redact.Add("nx-p4ssw0rd")
+
+	diags := diag.Diagnostics{}
+	AddErrorf(&diags, "Error Summary", "login failed for admin:%s", "nx-p4ssw0rd")
+	AddErrorAndReturn(&diags, "Error Summary", "login failed for admin:nx-p4ssw0rd")
+
+	for _, d := range diags {
+		if d.Detail() != "login failed for admin:***" {
+			t.Fatalf("Error detail = %s, expected the secret to be redacted", d.Detail())
+		}
+	}
+}
+
 func TestAddErrorAndReturn(t *testing.T) {
 	diags := diag.Diagnostics{}
 	result := AddErrorAndReturn(&diags, "Error Summary", "Error Details")
@@ -256,3 +273,136 @@ func TestAddErrorAndReturn(t *testing.T) {
 		t.Fatalf("Expected 1 error, got %d", len(errors))
 	}
 }
+
+type testClient struct {
+	name string
+}
+
+type testAuth struct {
+	username string
+}
+
+type MockTypedProvider struct {
+	auth    testAuth
+	baseURL string
+	client  *testClient
+}
+
+func (m *MockTypedProvider) GetAuth() testAuth {
+	return m.auth
+}
+
+func (m *MockTypedProvider) GetBaseURL() string {
+	return m.baseURL
+}
+
+func (m *MockTypedProvider) GetClient() *testClient {
+	return m.client
+}
+
+func TestTypedConfigure_TypedProvider(t *testing.T) {
+	res := &TypedBaseResource[*testClient, testAuth]{}
+	client := &testClient{name: "typed"}
+
+	req := resource.ConfigureRequest{
+		ProviderData: &MockTypedProvider{
+			auth:    testAuth{username: "admin"},
+			baseURL: "http://typed.example.com",
+			client:  client,
+		},
+	}
+	resp := &resource.ConfigureResponse{}
+
+	res.Configure(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure returned unexpected errors: %v", resp.Diagnostics)
+	}
+	if res.GetClient() != client {
+		t.Fatalf("GetClient() = %v, expected %v", res.GetClient(), client)
+	}
+	if res.GetAuth().username != "admin" {
+		t.Fatalf("GetAuth().username = %s, expected 'admin'", res.GetAuth().username)
+	}
+	if res.GetBaseURL() != "http://typed.example.com" {
+		t.Fatalf("GetBaseURL() = %s, expected 'http://typed.example.com'", res.GetBaseURL())
+	}
+	if !res.IsConfigured() {
+		t.Fatal("IsConfigured() should return true after a successful Configure")
+	}
+}
+
+func TestTypedConfigure_UntypedProviderWithMatchingValues(t *testing.T) {
+	res := &TypedBaseResource[*testClient, testAuth]{}
+	client := &testClient{name: "untyped"}
+
+	req := resource.ConfigureRequest{
+		ProviderData: &MockProvider{
+			auth:    testAuth{username: "admin"},
+			baseURL: "http://example.com",
+			client:  client,
+		},
+	}
+	resp := &resource.ConfigureResponse{}
+
+	res.Configure(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure returned unexpected errors: %v", resp.Diagnostics)
+	}
+	if res.GetClient() != client {
+		t.Fatalf("GetClient() = %v, expected %v", res.GetClient(), client)
+	}
+	if res.GetAuth().username != "admin" {
+		t.Fatalf("GetAuth().username = %s, expected 'admin'", res.GetAuth().username)
+	}
+}
+
+func TestTypedConfigure_UntypedProviderWithMismatchedClient(t *testing.T) {
+	res := &TypedBaseResource[*testClient, testAuth]{}
+
+	req := resource.ConfigureRequest{
+		ProviderData: &MockProvider{
+			auth:    testAuth{},
+			baseURL: "http://example.com",
+			client:  "wrong-client",
+		},
+	}
+	resp := &resource.ConfigureResponse{}
+
+	res.Configure(context.Background(), req, resp)
+
+	if len(resp.Diagnostics.Errors()) != 1 {
+		t.Fatalf("Expected exactly 1 error, got %d", len(resp.Diagnostics.Errors()))
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Unexpected Data Type" {
+		t.Fatalf("Error summary = %s, expected 'Unexpected Data Type'", resp.Diagnostics.Errors()[0].Summary())
+	}
+	if res.IsConfigured() {
+		t.Fatal("IsConfigured() should return false after a failed Configure")
+	}
+}
+
+func TestTypedIsConfigured_NilPointerClient(t *testing.T) {
+	res := NewTypedBaseResource(&TypedBaseResourceConfig[*testClient, testAuth]{
+		BaseURL: "http://example.com",
+	})
+
+	if res.IsConfigured() {
+		t.Fatal("IsConfigured() should return false when the typed client is a nil pointer")
+	}
+	if res.GetClient() != nil {
+		t.Fatalf("GetClient() = %v, expected nil", res.GetClient())
+	}
+}
+
+func TestTypedGetters_NilConfig(t *testing.T) {
+	res := &TypedBaseResource[*testClient, testAuth]{}
+
+	if res.GetClient() != nil {
+		t.Fatalf("GetClient() = %v, expected nil", res.GetClient())
+	}
+	if res.GetAuth() != (testAuth{}) {
+		t.Fatalf("GetAuth() = %v, expected zero value", res.GetAuth())
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+// baseUnderTest is the accessor set shared by the data source, ephemeral resource and function bases
+type baseUnderTest[C any, A any] interface {
+	GetAuth() A
+	GetBaseURL() string
+	GetClient() C
+	IsConfigured() bool
+}
+
+// configureFunc adapts the different Configure signatures of the bases
+type configureFunc func(ctx context.Context, providerData interface{}) diag.Diagnostics
+
+func dataSourceUnderTest[C any, A any](config *TypedBaseResourceConfig[C, A]) (baseUnderTest[C, A], configureFunc) {
+	d := NewTypedBaseDataSource(config)
+	return d, func(ctx context.Context, providerData interface{}) diag.Diagnostics {
+		resp := &datasource.ConfigureResponse{}
+		d.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, resp)
+		return resp.Diagnostics
+	}
+}
+
+func ephemeralResourceUnderTest[C any, A any](config *TypedBaseResourceConfig[C, A]) (baseUnderTest[C, A], configureFunc) {
+	e := NewTypedBaseEphemeralResource(config)
+	return e, func(ctx context.Context, providerData interface{}) diag.Diagnostics {
+		resp := &ephemeral.ConfigureResponse{}
+		e.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: providerData}, resp)
+		return resp.Diagnostics
+	}
+}
+
+func functionUnderTest[C any, A any](config *TypedBaseResourceConfig[C, A]) (baseUnderTest[C, A], configureFunc) {
+	f := NewTypedBaseFunction(config)
+	return f, f.Configure
+}
+
+var baseKinds = []struct {
+	name     string
+	newBase  func(config *BaseResourceConfig) (baseUnderTest[interface{}, interface{}], configureFunc)
+	newTyped func(config *TypedBaseResourceConfig[*testClient, testAuth]) (baseUnderTest[*testClient, testAuth], configureFunc)
+}{
+	{"data source", dataSourceUnderTest[interface{}, interface{}], dataSourceUnderTest[*testClient, testAuth]},
+	{"ephemeral resource", ephemeralResourceUnderTest[interface{}, interface{}], ephemeralResourceUnderTest[*testClient, testAuth]},
+	{"function", functionUnderTest[interface{}, interface{}], functionUnderTest[*testClient, testAuth]},
+}
+
+func TestBases_NewWithConfig(t *testing.T) {
+	for _, kind := range baseKinds {
+		t.Run(kind.name, func(t *testing.T) {
+			base, _ := kind.newBase(&BaseResourceConfig{
+				Auth:    "test-auth",
+				BaseURL: "http://example.com",
+				Client:  "test-client",
+			})
+
+			if base.GetAuth() != "test-auth" {
+				t.Fatalf("GetAuth() = %v, expected 'test-auth'", base.GetAuth())
+			}
+			if base.GetBaseURL() != "http://example.com" {
+				t.Fatalf("GetBaseURL() = %s, expected 'http://example.com'", base.GetBaseURL())
+			}
+			if base.GetClient() != "test-client" {
+				t.Fatalf("GetClient() = %v, expected 'test-client'", base.GetClient())
+			}
+			if !base.IsConfigured() {
+				t.Fatal("IsConfigured() should return true when config and client are set")
+			}
+		})
+	}
+}
+
+func TestBases_Configure(t *testing.T) {
+	client := &testClient{name: "typed"}
+	tests := []struct {
+		name           string
+		providerData   interface{}
+		expectedError  string
+		wantConfigured bool
+	}{
+		{
+			name:           "typed provider",
+			providerData:   &MockTypedProvider{auth: testAuth{username: "admin"}, baseURL: "http://example.com", client: client},
+			wantConfigured: true,
+		},
+		{
+			name:          "invalid provider",
+			providerData:  "invalid-provider",
+			expectedError: "Unexpected Data Type",
+		},
+		{
+			name: "nil provider data",
+		},
+	}
+
+	for _, kind := range baseKinds {
+		for _, tt := range tests {
+			t.Run(kind.name+"/"+tt.name, func(t *testing.T) {
+				base, configure := kind.newTyped(nil)
+
+				diags := configure(context.Background(), tt.providerData)
+
+				if tt.expectedError == "" && diags.HasError() {
+					t.Fatalf("Configure returned unexpected errors: %v", diags)
+				}
+				if tt.expectedError != "" && (!diags.HasError() || diags.Errors()[0].Summary() != tt.expectedError) {
+					t.Fatalf("diagnostics = %v, expected %q", diags, tt.expectedError)
+				}
+				if base.IsConfigured() != tt.wantConfigured {
+					t.Fatalf("IsConfigured() = %t, expected %t", base.IsConfigured(), tt.wantConfigured)
+				}
+				if tt.wantConfigured && (base.GetClient() != client || base.GetAuth().username != "admin") {
+					t.Fatalf("GetClient(), GetAuth() = %v, %v, expected the provider's client and auth", base.GetClient(), base.GetAuth())
+				}
+			})
+		}
+	}
+}
+
+func TestFunctionRequireConfigured(t *testing.T) {
+	if (&BaseFunction{}).RequireConfigured() == nil {
+		t.Fatal("RequireConfigured() should return an error when the function is not configured")
+	}
+	if NewBaseFunction(&BaseResourceConfig{Client: "test-client"}).RequireConfigured() != nil {
+		t.Fatal("RequireConfigured() should return nil when the function is configured")
+	}
+}
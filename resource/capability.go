@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+// gatedAttribute is an attribute carrying server requirements
+type gatedAttribute struct {
+	expression   path.Expression
+	requirements []schema.ServerRequirement
+}
+
+// CheckServerRequirements adds an error for every configured attribute whose schema.ServerRequirement
+// the server does not meet. The server is only contacted when such an attribute is set. When the provider
+// reports no server nothing is checked, and when detection fails a warning is added instead.
+//
+// The check is not run automatically. Resources with marked attributes call it from their ModifyPlan,
+// skipping destroy plans:
+//
+//	func (r *repositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+//		if req.Plan.Raw.IsNull() {
+//			return
+//		}
+//		r.CheckServerRequirements(ctx, req.Config, &resp.Diagnostics)
+//	}
+func (r *TypedBaseResource[C, A]) CheckServerRequirements(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	resourceSchema, ok := config.Schema.(resourceschema.Schema)
+	if !ok {
+		return
+	}
+	gated := gatedAttributes(resourceSchema.Attributes, resourceSchema.Blocks, path.Expression{})
+	if len(gated) == 0 {
+		return
+	}
+
+	type configured struct {
+		path         path.Path
+		requirements []schema.ServerRequirement
+	}
+	var set []configured
+	for _, attribute := range gated {
+		paths, pathDiags := config.PathMatches(ctx, attribute.expression)
+		diags.Append(pathDiags...)
+		for _, attributePath := range paths {
+			var value attr.Value
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			if value != nil && !value.IsNull() {
+				set = append(set, configured{path: attributePath, requirements: attribute.requirements})
+			}
+		}
+	}
+	if len(set) == 0 || diags.HasError() {
+		return
+	}
+
+	source := r.GetServer()
+	if source == nil {
+		tflog.Debug(ctx, "Skipping server requirement checks, the provider reports no server version")
+		return
+	}
+	info, err := source.Info(ctx)
+	if err != nil {
+		errors.AddWarningWithCode(
+			diags,
+			errors.CodeUnsupportedByServer,
+			"Server Version Unknown",
+			fmt.Sprintf("The configuration uses attributes that require a specific server version or edition, but the server version could not be detected: %s. The plan continues without checking them.", err),
+		)
+		return
+	}
+
+	for _, attribute := range set {
+		for _, requirement := range attribute.requirements {
+			satisfied, err := requirement.SatisfiedBy(info)
+			if err != nil || satisfied {
+				continue
+			}
+			errors.AddAttributeErrorWithCode(
+				diags,
+				attribute.path,
+				errors.CodeUnsupportedByServer,
+				"Attribute Not Supported by Server",
+				fmt.Sprintf(
+					"%q requires %s, but %s runs %s. Remove %q from the configuration or upgrade the server.",
+					attribute.path.String(), requirement, serverName(r.GetBaseURL()), info, attribute.path.String(),
+				),
+			)
+		}
+	}
+}
+
+// serverName names the server in messages by its URL when known
+func serverName(baseURL string) string {
+	if baseURL == "" {
+		return "the server"
+	}
+	return "the server at " + baseURL
+}
+
+// gatedAttributes walks a resource schema for attributes and blocks with server requirements
+func gatedAttributes(attributes map[string]resourceschema.Attribute, blocks map[string]resourceschema.Block, parent path.Expression) []gatedAttribute {
+	var gated []gatedAttribute
+	add := func(expression path.Expression, requirements []schema.ServerRequirement) {
+		if len(requirements) > 0 {
+			gated = append(gated, gatedAttribute{expression: expression, requirements: requirements})
+		}
+	}
+
+	for name, attribute := range attributes {
+		expression := parent.AtName(name)
+		switch a := attribute.(type) {
+		case resourceschema.StringAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.BoolAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.Int32Attribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.Int64Attribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.Float64Attribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.ListAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.SetAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.MapAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.ObjectAttribute:
+			add(expression, requirementsIn(a.Validators))
+		case resourceschema.SingleNestedAttribute:
+			add(expression, requirementsIn(a.Validators))
+			gated = append(gated, gatedAttributes(a.Attributes, nil, expression)...)
+		case resourceschema.ListNestedAttribute:
+			add(expression, requirementsIn(a.Validators))
+			gated = append(gated, gatedAttributes(a.NestedObject.Attributes, nil, expression.AtAnyListIndex())...)
+		case resourceschema.SetNestedAttribute:
+			add(expression, requirementsIn(a.Validators))
+			gated = append(gated, gatedAttributes(a.NestedObject.Attributes, nil, expression.AtAnySetValue())...)
+		case resourceschema.MapNestedAttribute:
+			add(expression, requirementsIn(a.Validators))
+			gated = append(gated, gatedAttributes(a.NestedObject.Attributes, nil, expression.AtAnyMapKey())...)
+		}
+	}
+
+	for name, block := range blocks {
+		expression := parent.AtName(name)
+		switch b := block.(type) {
+		case resourceschema.SingleNestedBlock:
+			add(expression, requirementsIn(b.Validators))
+			gated = append(gated, gatedAttributes(b.Attributes, b.Blocks, expression)...)
+		case resourceschema.ListNestedBlock:
+			add(expression, requirementsIn(b.Validators))
+			gated = append(gated, gatedAttributes(b.NestedObject.Attributes, b.NestedObject.Blocks, expression.AtAnyListIndex())...)
+		case resourceschema.SetNestedBlock:
+			add(expression, requirementsIn(b.Validators))
+			gated = append(gated, gatedAttributes(b.NestedObject.Attributes, b.NestedObject.Blocks, expression.AtAnySetValue())...)
+		}
+	}
+	return gated
+}
+
+// requirementsIn returns the server requirements among an attribute's validators
+func requirementsIn[V any](validators []V) []schema.ServerRequirement {
+	var requirements []schema.ServerRequirement
+	for _, v := range validators {
+		if requirement, ok := any(v).(schema.ServerRequirement); ok {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/server"
+)
+
+type countingSource struct {
+	info  *server.Info
+	err   error
+	calls int
+}
+
+func (s *countingSource) Info(_ context.Context) (*server.Info, error) {
+	s.calls++
+	return s.info, s.err
+}
+
+func gatedSchema() resourceschema.Schema {
+	return resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"name": resourceschema.StringAttribute{Required: true},
+			"content_max_age": resourceschema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{schema.SinceVersion("3.70.0")},
+			},
+			"rules": resourceschema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: resourceschema.NestedAttributeObject{Attributes: map[string]resourceschema.Attribute{
+					"routing": resourceschema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{schema.RequiresEdition(server.EditionPro)},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func gatedPlanRequest(t *testing.T, maxAge interface{}, routing ...interface{}) resource.ModifyPlanRequest {
+	t.Helper()
+	s := gatedSchema()
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	ruleType := objectType.AttributeTypes["rules"].(tftypes.List).ElementType.(tftypes.Object)
+
+	var rules interface{}
+	if len(routing) > 0 {
+		var values []tftypes.Value
+		for _, value := range routing {
+			values = append(values, tftypes.NewValue(ruleType, map[string]tftypes.Value{"routing": tftypes.NewValue(tftypes.String, value)}))
+		}
+		rules = values
+	}
+	raw := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "maven-releases"),
+		"content_max_age": tftypes.NewValue(tftypes.Number, maxAge),
+		"rules":           tftypes.NewValue(objectType.AttributeTypes["rules"], rules),
+	})
+	return resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: s, Raw: raw}, Plan: tfsdk.Plan{Schema: s, Raw: raw}}
+}
+
+func gatedResource(source server.Source) *BaseResource {
+	return NewBaseResource(&BaseResourceConfig{BaseURL: "https://nexus.example.com", Client: "client", Server: source})
+}
+
+func TestCheckServerRequirements_ServerRequirements(t *testing.T) {
+	oss := &server.Info{Product: server.ProductNexusRepository, Version: server.Version{Major: 3, Minor: 68, Patch: 1, Build: "02"}, Edition: server.EditionOSS}
+	pro := &server.Info{Product: server.ProductNexusRepository, Version: server.Version{Major: 3, Minor: 70}, Edition: server.EditionPro}
+
+	tests := []struct {
+		name     string
+		info     *server.Info
+		req      resource.ModifyPlanRequest
+		expected []path.Path
+	}{
+		{"supported", pro, gatedPlanRequest(t, 1440, "block"), nil},
+		{"version too old", oss, gatedPlanRequest(t, 1440), []path.Path{path.Root("content_max_age")}},
+		{"wrong edition in nested attribute", oss, gatedPlanRequest(t, nil, nil, "block"), []path.Path{path.Root("rules").AtListIndex(1).AtName("routing")}},
+		{"gated attributes unset", oss, gatedPlanRequest(t, nil, nil), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{Plan: tt.req.Plan}
+			gatedResource(server.Static(*tt.info)).CheckServerRequirements(context.Background(), tt.req.Config, &resp.Diagnostics)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("CheckServerRequirements returned %d errors, expected %d: %v", len(errs), len(tt.expected), resp.Diagnostics)
+			}
+			for i, d := range errs {
+				withPath, ok := d.(interface{ Path() path.Path })
+				if !ok || !withPath.Path().Equal(tt.expected[i]) {
+					t.Fatalf("error %d is not on %s: %v", i, tt.expected[i], d)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckServerRequirements_ServerRequirementMessage(t *testing.T) {
+	info := &server.Info{Product: server.ProductNexusRepository, Version: server.Version{Major: 3, Minor: 68, Patch: 1, Build: "02"}, Edition: server.EditionPro}
+	req := gatedPlanRequest(t, 1440)
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	gatedResource(server.Static(*info)).CheckServerRequirements(context.Background(), req.Config, &resp.Diagnostics)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("CheckServerRequirements should reject an attribute the server does not support")
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	expected := `"content_max_age" requires version 3.70.0 or later, but the server at https://nexus.example.com runs Nexus Repository 3.68.1-02 (PRO).`
+	if !strings.Contains(detail, expected) || !strings.Contains(detail, "SONA-E-005") {
+		t.Fatalf("Detail = %q, expected it to contain %q and the error code", detail, expected)
+	}
+}
+
+func TestCheckServerRequirements_DetectsOnlyWhenNeeded(t *testing.T) {
+	source := &countingSource{info: &server.Info{Version: server.Version{Major: 3, Minor: 70}}}
+	r := gatedResource(source)
+
+	req := gatedPlanRequest(t, nil)
+	r.CheckServerRequirements(context.Background(), req.Config, &diag.Diagnostics{})
+	if source.calls != 0 {
+		t.Fatal("CheckServerRequirements should not detect the server when no gated attribute is set")
+	}
+
+	req = gatedPlanRequest(t, 1440)
+	r.CheckServerRequirements(context.Background(), req.Config, &diag.Diagnostics{})
+	if source.calls != 1 {
+		t.Fatalf("detected the server %d times, expected 1", source.calls)
+	}
+}
+
+func TestCheckServerRequirements_DetectionFailureWarns(t *testing.T) {
+	req := gatedPlanRequest(t, 1440)
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	gatedResource(&countingSource{err: fmt.Errorf("connection refused")}).CheckServerRequirements(context.Background(), req.Config, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("CheckServerRequirements returned %v, expected a single warning", resp.Diagnostics)
+	}
+
+	resp = &resource.ModifyPlanResponse{Plan: req.Plan}
+	gatedResource(nil).CheckServerRequirements(context.Background(), req.Config, &resp.Diagnostics)
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("CheckServerRequirements returned %v, expected no checks without a server source", resp.Diagnostics)
+	}
+}
+
+func TestBaseResource_ModifyPlanIsOptIn(t *testing.T) {
+	if _, ok := interface{}(&BaseResource{}).(resource.ResourceWithModifyPlan); ok {
+		t.Fatal("BaseResource should leave ModifyPlan to the resources that embed it")
+	}
+}
+
+func TestConfigure_ServerProvider(t *testing.T) {
+	source := server.Static{Product: server.ProductNexusRepository}
+	r := NewBaseResource(nil)
+	resp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &BaseResourceConfig{Client: "client", Server: source}}, resp)
+
+	if resp.Diagnostics.HasError() || r.GetServer() != source {
+		t.Fatalf("GetServer() = %v, expected the provider's server source", r.GetServer())
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/server"
+)
+
+// TypedBaseDataSource provides common functionality for all Terraform data sources.
+// C is the API client type and A the authentication type handed over by the provider.
+type TypedBaseDataSource[C any, A any] struct {
+	config *TypedBaseResourceConfig[C, A]
+}
+
+// BaseDataSource provides common functionality for all Terraform data sources
+type BaseDataSource = TypedBaseDataSource[interface{}, interface{}]
+
+// NewTypedBaseDataSource creates a new TypedBaseDataSource with the given configuration
+func NewTypedBaseDataSource[C any, A any](config *TypedBaseResourceConfig[C, A]) *TypedBaseDataSource[C, A] {
+	return &TypedBaseDataSource[C, A]{
+		config: config,
+	}
+}
+
+// NewBaseDataSource creates a new BaseDataSource with the given configuration
+func NewBaseDataSource(config *BaseResourceConfig) *BaseDataSource {
+	return NewTypedBaseDataSource(config)
+}
+
+// Configure sets up the data source with provider configuration
+func (d *TypedBaseDataSource[C, A]) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if config := configFromProviderData[C, A](req.ProviderData, &resp.Diagnostics); config != nil {
+		d.config = config
+	}
+}
+
+// GetAuth returns the authentication configuration
+func (d *TypedBaseDataSource[C, A]) GetAuth() A {
+	return d.config.GetAuth()
+}
+
+// GetBaseURL returns the API base URL
+func (d *TypedBaseDataSource[C, A]) GetBaseURL() string {
+	return d.config.GetBaseURL()
+}
+
+// GetClient returns the API client
+func (d *TypedBaseDataSource[C, A]) GetClient() C {
+	return d.config.GetClient()
+}
+
+// GetServer returns the source of the server version and edition, or nil when the provider reports none
+func (d *TypedBaseDataSource[C, A]) GetServer() server.Source {
+	return d.config.GetServer()
+}
+
+// IsConfigured checks if the data source has been properly configured
+func (d *TypedBaseDataSource[C, A]) IsConfigured() bool {
+	return d.config.IsConfigured()
+}
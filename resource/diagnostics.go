@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	// DefaultMaxDiagnosticGroups is the default number of distinct diagnostics flushed by a DiagnosticsCollector
+	DefaultMaxDiagnosticGroups = 10
+	// DefaultMaxListedIdentifiers is the default number of affected identifiers listed per diagnostic
+	DefaultMaxListedIdentifiers = 20
+)
+
+// DiagnosticsCollector groups diagnostics from bulk operations, such as updating the members of a set,
+// so that many members failing the same way produce one diagnostic listing the affected members.
+// It is safe for concurrent use.
+type DiagnosticsCollector struct {
+	// MaxGroups is the number of distinct diagnostics flushed; the rest are summarised in one final diagnostic
+	MaxGroups int
+	// MaxIdentifiers is the number of affected identifiers listed per diagnostic
+	MaxIdentifiers int
+
+	mu     sync.Mutex
+	groups []*diagnosticGroup
+	index  map[diagnosticKey]*diagnosticGroup
+}
+
+// diagnosticKey identifies diagnostics that are grouped together
+type diagnosticKey struct {
+	severity diag.Severity
+	summary  string
+	path     string
+}
+
+// diagnosticGroup holds the diagnostics collected under one key
+type diagnosticGroup struct {
+	key         diagnosticKey
+	path        *path.Path
+	detail      string
+	details     map[string]struct{}
+	count       int
+	identifiers []string
+}
+
+// NewDiagnosticsCollector creates a collector with the default limits
+func NewDiagnosticsCollector() *DiagnosticsCollector {
+	return &DiagnosticsCollector{
+		MaxGroups:      DefaultMaxDiagnosticGroups,
+		MaxIdentifiers: DefaultMaxListedIdentifiers,
+	}
+}
+
+// Append collects diagnostics for the object with the given identifier, e.g. diagnostics added by the errors helpers
+func (c *DiagnosticsCollector) Append(identifier string, diags ...diag.Diagnostic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, d := range diags {
+		c.add(identifier, d)
+	}
+}
+
+// AddError collects an error for the object with the given identifier
+func (c *DiagnosticsCollector) AddError(identifier string, summary string, detail string) {
+	c.Append(identifier, diag.NewErrorDiagnostic(summary, detail))
+}
+
+// AddErrorf collects a formatted error for the object with the given identifier
+func (c *DiagnosticsCollector) AddErrorf(identifier string, summary string, format string, args ...interface{}) {
+	diags := diag.Diagnostics{}
+	AddErrorf(&diags, summary, format, args...)
+	c.Append(identifier, diags...)
+}
+
+// AddWarning collects a warning for the object with the given identifier
+func (c *DiagnosticsCollector) AddWarning(identifier string, summary string, detail string) {
+	c.Append(identifier, diag.NewWarningDiagnostic(summary, detail))
+}
+
+// HasError reports whether any error has been collected
+func (c *DiagnosticsCollector) HasError() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, group := range c.groups {
+		if group.key.severity == diag.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of diagnostics collected, before grouping
+func (c *DiagnosticsCollector) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := 0
+	for _, group := range c.groups {
+		count += group.count
+	}
+	return count
+}
+
+// Flush adds the grouped diagnostics to diags, in the order they were first collected, and empties the collector
+func (c *DiagnosticsCollector) Flush(diags *diag.Diagnostics) {
+	c.mu.Lock()
+	groups := c.groups
+	c.groups, c.index = nil, nil
+	c.mu.Unlock()
+
+	maxGroups := c.MaxGroups
+	if maxGroups <= 0 || maxGroups > len(groups) {
+		maxGroups = len(groups)
+	}
+	for _, group := range groups[:maxGroups] {
+		diags.Append(group.diagnostic(c.MaxIdentifiers))
+	}
+
+	omitted, omittedErrors := 0, false
+	for _, group := range groups[maxGroups:] {
+		omitted += group.count
+		omittedErrors = omittedErrors || group.key.severity == diag.SeverityError
+	}
+	if omitted == 0 {
+		return
+	}
+	summary := "Further Diagnostics Omitted"
+	detail := fmt.Sprintf("%d further diagnostic(s) of %d other kind(s) were omitted. Fix the issues above and apply again to see them.", omitted, len(groups)-maxGroups)
+	if omittedErrors {
+		diags.AddError(summary, detail)
+	} else {
+		diags.AddWarning(summary, detail)
+	}
+}
+
+// add collects a single diagnostic; the caller must hold the lock
+func (c *DiagnosticsCollector) add(identifier string, d diag.Diagnostic) {
+	key := diagnosticKey{severity: d.Severity(), summary: d.Summary()}
+	var attributePath *path.Path
+	if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+		p := withPath.Path()
+		attributePath, key.path = &p, p.String()
+	}
+
+	if c.index == nil {
+		c.index = map[diagnosticKey]*diagnosticGroup{}
+	}
+	group, ok := c.index[key]
+	if !ok {
+		group = &diagnosticGroup{key: key, path: attributePath, detail: d.Detail(), details: map[string]struct{}{}}
+		c.index[key] = group
+		c.groups = append(c.groups, group)
+	}
+	group.count++
+	group.details[d.Detail()] = struct{}{}
+	if identifier != "" {
+		group.identifiers = append(group.identifiers, identifier)
+	}
+}
+
+// diagnostic builds the flushed diagnostic for the group
+func (g *diagnosticGroup) diagnostic(maxIdentifiers int) diag.Diagnostic {
+	summary, detail := g.key.summary, g.detail
+	if g.count > 1 {
+		summary = fmt.Sprintf("%s (%d occurrences)", summary, g.count)
+	}
+	if len(g.details) > 1 {
+		detail = fmt.Sprintf("%s\n\n%d different details were reported; only the first is shown.", detail, len(g.details))
+	}
+	if len(g.identifiers) > 0 {
+		detail = fmt.Sprintf("%s\n\nAffected: %s", detail, listIdentifiers(g.identifiers, maxIdentifiers))
+	}
+
+	switch {
+	case g.path != nil && g.key.severity == diag.SeverityWarning:
+		return diag.NewAttributeWarningDiagnostic(*g.path, summary, detail)
+	case g.path != nil:
+		return diag.NewAttributeErrorDiagnostic(*g.path, summary, detail)
+	case g.key.severity == diag.SeverityWarning:
+		return diag.NewWarningDiagnostic(summary, detail)
+	default:
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+}
+
+// listIdentifiers joins up to max identifiers, noting how many were left out
+func listIdentifiers(identifiers []string, max int) string {
+	if max <= 0 || len(identifiers) <= max {
+		return strings.Join(identifiers, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(identifiers[:max], ", "), len(identifiers)-max)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+func TestDiagnosticsCollector_GroupsEqualSummaries(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	for i := 0; i < 40; i++ {
+		collector.AddErrorf(fmt.Sprintf("member-%02d", i), "Error adding member", "repository %q does not exist", "missing")
+	}
+	collector.AddError("member-40", "Error removing member", "locked")
+
+	diags := diag.Diagnostics{}
+	collector.Flush(&diags)
+
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 grouped diagnostics, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Summary() != "Error adding member (40 occurrences)" {
+		t.Fatalf("unexpected summary: %q", diags[0].Summary())
+	}
+	if !strings.Contains(diags[0].Detail(), "Affected: member-00, member-01") || !strings.HasSuffix(diags[0].Detail(), "and 20 more") {
+		t.Fatalf("expected the first identifiers and a count of the rest, got: %q", diags[0].Detail())
+	}
+	if diags[1].Summary() != "Error removing member" || !strings.HasSuffix(diags[1].Detail(), "Affected: member-40") {
+		t.Fatalf("unexpected single diagnostic: %v", diags[1])
+	}
+	if collector.Count() != 0 {
+		t.Fatal("Flush should empty the collector")
+	}
+}
+
+func TestDiagnosticsCollector_ErrorsHelpers(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	for _, member := range []string{"maven-central", "npm-proxy"} {
+		memberDiags := diag.Diagnostics{}
+		errors.AddAPIErrorDiagnostic(&memberDiags, "updating", "group member", nil, fmt.Errorf("%s: %w", member, errors.NewResponseError(&http.Response{StatusCode: http.StatusBadRequest}, nil)))
+		collector.Append(member, memberDiags...)
+	}
+
+	diags := diag.Diagnostics{}
+	collector.Flush(&diags)
+
+	if len(diags) != 1 || !strings.HasSuffix(diags[0].Detail(), "Affected: maven-central, npm-proxy") {
+		t.Fatalf("expected one group listing both members, got: %v", diags)
+	}
+}
+
+func TestDiagnosticsCollector_DifferentDetails(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	collector.AddErrorf("maven-central", "Error updating member", "member %s is offline", "maven-central")
+	collector.AddErrorf("npm-proxy", "Error updating member", "member %s is offline", "npm-proxy")
+
+	diags := diag.Diagnostics{}
+	collector.Flush(&diags)
+
+	if len(diags) != 1 || !strings.HasPrefix(diags[0].Detail(), "member maven-central is offline") || !strings.Contains(diags[0].Detail(), "2 different details were reported") {
+		t.Fatalf("expected one group showing the first detail, got: %v", diags)
+	}
+}
+
+func TestDiagnosticsCollector_KeepsPathsAndSeverity(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	collector.Append("a", diag.NewAttributeWarningDiagnostic(path.Root("members"), "Member ignored", "duplicate"))
+	collector.Append("b", diag.NewAttributeWarningDiagnostic(path.Root("members"), "Member ignored", "duplicate"))
+	collector.Append("c", diag.NewAttributeWarningDiagnostic(path.Root("name"), "Member ignored", "duplicate"))
+
+	if collector.HasError() {
+		t.Fatal("HasError should be false when only warnings were collected")
+	}
+
+	diags := diag.Diagnostics{}
+	collector.Flush(&diags)
+
+	if diags.WarningsCount() != 2 {
+		t.Fatalf("expected diagnostics on different attributes to stay separate, got: %v", diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("members")) {
+		t.Fatalf("expected the grouped warning to keep its path, got: %v", diags[0])
+	}
+}
+
+func TestDiagnosticsCollector_CapsGroups(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	collector.MaxGroups = 2
+	for i := 0; i < 5; i++ {
+		collector.AddError(fmt.Sprintf("member-%d", i), fmt.Sprintf("Error %d", i), "failed")
+	}
+
+	diags := diag.Diagnostics{}
+	collector.Flush(&diags)
+
+	if len(diags) != 3 || diags[2].Summary() != "Further Diagnostics Omitted" || diags[2].Severity() != diag.SeverityError {
+		t.Fatalf("expected 2 diagnostics and an omitted error, got: %v", diags)
+	}
+	if !strings.HasPrefix(diags[2].Detail(), "3 further diagnostic(s) of 3 other kind(s)") {
+		t.Fatalf("unexpected omitted detail: %q", diags[2].Detail())
+	}
+}
+
+func TestDiagnosticsCollector_Concurrent(t *testing.T) {
+	collector := NewDiagnosticsCollector()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			collector.AddError(fmt.Sprintf("member-%d", i), "Error updating member", "failed")
+		}(i)
+	}
+	wg.Wait()
+
+	if collector.Count() != 50 || !collector.HasError() {
+		t.Fatalf("expected 50 collected errors, got %d", collector.Count())
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+// TypedBaseEphemeralResource provides common functionality for all Terraform ephemeral resources.
+// C is the API client type and A the authentication type handed over by the provider.
+type TypedBaseEphemeralResource[C any, A any] struct {
+	config *TypedBaseResourceConfig[C, A]
+}
+
+// BaseEphemeralResource provides common functionality for all Terraform ephemeral resources
+type BaseEphemeralResource = TypedBaseEphemeralResource[interface{}, interface{}]
+
+// NewTypedBaseEphemeralResource creates a new TypedBaseEphemeralResource with the given configuration
+func NewTypedBaseEphemeralResource[C any, A any](config *TypedBaseResourceConfig[C, A]) *TypedBaseEphemeralResource[C, A] {
+	return &TypedBaseEphemeralResource[C, A]{
+		config: config,
+	}
+}
+
+// NewBaseEphemeralResource creates a new BaseEphemeralResource with the given configuration
+func NewBaseEphemeralResource(config *BaseResourceConfig) *BaseEphemeralResource {
+	return NewTypedBaseEphemeralResource(config)
+}
+
+// Configure sets up the ephemeral resource with provider configuration
+func (e *TypedBaseEphemeralResource[C, A]) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if config := configFromProviderData[C, A](req.ProviderData, &resp.Diagnostics); config != nil {
+		e.config = config
+	}
+}
+
+// GetAuth returns the authentication configuration
+func (e *TypedBaseEphemeralResource[C, A]) GetAuth() A {
+	return e.config.GetAuth()
+}
+
+// GetBaseURL returns the API base URL
+func (e *TypedBaseEphemeralResource[C, A]) GetBaseURL() string {
+	return e.config.GetBaseURL()
+}
+
+// GetClient returns the API client
+func (e *TypedBaseEphemeralResource[C, A]) GetClient() C {
+	return e.config.GetClient()
+}
+
+// IsConfigured checks if the ephemeral resource has been properly configured
+func (e *TypedBaseEphemeralResource[C, A]) IsConfigured() bool {
+	return e.config.IsConfigured()
+}
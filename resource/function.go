@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// TypedBaseFunction provides common functionality for provider-defined functions.
+// The plugin framework does not pass provider data to functions, so the provider
+// is expected to call Configure from its Functions factory.
+type TypedBaseFunction[C any, A any] struct {
+	config *TypedBaseResourceConfig[C, A]
+}
+
+// BaseFunction provides common functionality for provider-defined functions
+type BaseFunction = TypedBaseFunction[interface{}, interface{}]
+
+// NewTypedBaseFunction creates a new TypedBaseFunction with the given configuration
+func NewTypedBaseFunction[C any, A any](config *TypedBaseResourceConfig[C, A]) *TypedBaseFunction[C, A] {
+	return &TypedBaseFunction[C, A]{
+		config: config,
+	}
+}
+
+// NewBaseFunction creates a new BaseFunction with the given configuration
+func NewBaseFunction(config *BaseResourceConfig) *BaseFunction {
+	return NewTypedBaseFunction(config)
+}
+
+// Configure sets up the function with provider configuration
+func (f *TypedBaseFunction[C, A]) Configure(ctx context.Context, providerData interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config := configFromProviderData[C, A](providerData, &diags); config != nil {
+		f.config = config
+	}
+	return diags
+}
+
+// RequireConfigured returns a function error when the function has not been configured.
+// Use it at the start of Run before accessing the client.
+func (f *TypedBaseFunction[C, A]) RequireConfigured() *function.FuncError {
+	if f.IsConfigured() {
+		return nil
+	}
+	return function.NewFuncError("Provider Not Configured: this function requires a configured provider. Please report this issue to the provider developers.")
+}
+
+// GetAuth returns the authentication configuration
+func (f *TypedBaseFunction[C, A]) GetAuth() A {
+	return f.config.GetAuth()
+}
+
+// GetBaseURL returns the API base URL
+func (f *TypedBaseFunction[C, A]) GetBaseURL() string {
+	return f.config.GetBaseURL()
+}
+
+// GetClient returns the API client
+func (f *TypedBaseFunction[C, A]) GetClient() C {
+	return f.config.GetClient()
+}
+
+// IsConfigured checks if the function has been properly configured
+func (f *TypedBaseFunction[C, A]) IsConfigured() bool {
+	return f.config.IsConfigured()
+}
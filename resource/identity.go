@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+// IdentityFromState implements resource.ResourceWithIdentity for resources whose identity
+// attributes mirror state attributes of the same name. Embed it next to TypedBaseResource; identity
+// is opt-in, since resources that advertise an identity must fill it in every operation.
+type IdentityFromState struct {
+	attributes map[string]identityschema.Attribute
+}
+
+// IdentityFromID returns an IdentityFromState with a single id attribute, filled from the id state attribute
+func IdentityFromID() IdentityFromState {
+	return NewIdentityFromState(schema.IdentityIDAttributes())
+}
+
+// NewIdentityFromState returns an IdentityFromState with the given identity attributes,
+// for example schema.IdentityStringAttributes("organization_id", "application_id")
+func NewIdentityFromState(attributes map[string]identityschema.Attribute) IdentityFromState {
+	return IdentityFromState{
+		attributes: attributes,
+	}
+}
+
+// IdentitySchema returns the identity schema for the resource
+func (i IdentityFromState) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: i.attributes,
+	}
+}
+
+// SetIdentityFromState copies every identity attribute from the state attribute of the same name.
+// It does nothing when the resource has no identity, so it is safe to call from any CRUD method.
+func SetIdentityFromState(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil || identity.Schema == nil {
+		return diags
+	}
+
+	for name := range identity.Schema.GetAttributes() {
+		var value attr.Value
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		diags.Append(identity.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	return diags
+}
+
+// importStateFromIdentity copies every identity attribute to the state attribute of the same name
+func importStateFromIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name := range identity.Schema.GetAttributes() {
+		var value attr.Value
+		diags.Append(identity.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		if value.IsNull() {
+			continue
+		}
+		diags.Append(state.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	return diags
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+func testIdentity(attributes map[string]identityschema.Attribute, values map[string]tftypes.Value) *tfsdk.ResourceIdentity {
+	s := identityschema.Schema{Attributes: attributes}
+	objectType := s.Type().TerraformType(context.Background())
+	raw := tftypes.NewValue(objectType, nil)
+	if values != nil {
+		raw = tftypes.NewValue(objectType, values)
+	}
+	return &tfsdk.ResourceIdentity{Schema: s, Raw: raw}
+}
+
+func TestIdentityFromID(t *testing.T) {
+	resp := &resource.IdentitySchemaResponse{}
+	IdentityFromID().IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, resp)
+
+	if _, ok := resp.IdentitySchema.Attributes["id"]; !ok {
+		t.Fatal("IdentityFromID should define an id identity attribute")
+	}
+	if diags := resp.IdentitySchema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("IdentityFromID should produce a valid identity schema: %v", diags)
+	}
+}
+
+func TestSetIdentityFromState(t *testing.T) {
+	s := testLifecycleSchema()
+	state := tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")}
+	identity := testIdentity(schema.IdentityIDAttributes(), nil)
+
+	diags := SetIdentityFromState(context.Background(), state, identity)
+	if diags.HasError() {
+		t.Fatalf("SetIdentityFromState returned unexpected errors: %v", diags)
+	}
+
+	var id types.String
+	identity.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "w-1" {
+		t.Fatalf("identity id = %s, expected 'w-1'", id.ValueString())
+	}
+}
+
+func TestSetIdentityFromState_NilIdentity(t *testing.T) {
+	s := testLifecycleSchema()
+	state := tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")}
+
+	if diags := SetIdentityFromState(context.Background(), state, nil); diags.HasError() {
+		t.Fatalf("SetIdentityFromState should ignore a nil identity: %v", diags)
+	}
+}
+
+func TestImportByID_ByIdentity(t *testing.T) {
+	res := ImportByID{}
+	identity := testIdentity(
+		schema.IdentityStringAttributes("organization_id", "application_id"),
+		map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+			"application_id":  tftypes.NewValue(tftypes.String, "app-1"),
+		},
+	)
+	resp := &resource.ImportStateResponse{State: testImportState()}
+
+	res.ImportState(context.Background(), resource.ImportStateRequest{Identity: identity}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model testImportModel
+	resp.State.Get(context.Background(), &model)
+	if model.OrganizationID.ValueString() != "org-1" || model.ApplicationID.ValueString() != "app-1" {
+		t.Fatalf("state = %v, expected org-1 and app-1 from the identity", model)
+	}
+}
+
+func TestLifecycleCreate_SetsIdentity(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{object: &testObject{ID: "w-1", Name: "widget"}})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "widget")}}
+	resp := &resource.CreateResponse{
+		State:    tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)},
+		Identity: testIdentity(schema.IdentityIDAttributes(), nil),
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var id types.String
+	resp.Identity.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "w-1" {
+		t.Fatalf("identity id = %s, expected 'w-1'", id.ValueString())
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultImportIDSeparator is the separator used between composite import ID parts
+const DefaultImportIDSeparator = "/"
+
+// ImportIDSpec describes a composite import identifier such as "organization_id/application_id".
+// Each part is written to the top-level attribute of the same name.
+type ImportIDSpec struct {
+	attributes []string
+	separator  string
+}
+
+// ImportID creates an import ID spec for the given attributes, in the order they appear in the identifier
+func ImportID(attributes ...string) *ImportIDSpec {
+	return &ImportIDSpec{
+		attributes: attributes,
+		separator:  DefaultImportIDSeparator,
+	}
+}
+
+// Separator sets the separator between identifier parts
+func (s *ImportIDSpec) Separator(separator string) *ImportIDSpec {
+	s.separator = separator
+	return s
+}
+
+// Attributes returns the attribute names of the identifier parts
+func (s *ImportIDSpec) Attributes() []string {
+	return s.attributes
+}
+
+// Format returns the expected identifier format, e.g. "organization_id/application_id"
+func (s *ImportIDSpec) Format() string {
+	return strings.Join(s.attributes, s.separator)
+}
+
+// Build joins the given parts into an import identifier
+func (s *ImportIDSpec) Build(parts ...string) string {
+	return strings.Join(parts, s.separator)
+}
+
+// Parse splits an import identifier into its parts, keyed by attribute name.
+// It returns an error if the number of parts is wrong or any part is empty.
+func (s *ImportIDSpec) Parse(id string) (map[string]string, error) {
+	parts := strings.Split(id, s.separator)
+	if len(s.attributes) == 1 {
+		parts = []string{id}
+	}
+	if len(parts) != len(s.attributes) {
+		return nil, fmt.Errorf("expected import identifier with format '%s', got: '%s' (%d part(s) separated by '%s', expected %d)",
+			s.Format(), id, len(parts), s.separator, len(s.attributes))
+	}
+
+	values := make(map[string]string, len(parts))
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return nil, fmt.Errorf("expected import identifier with format '%s', got: '%s' (%s is empty)", s.Format(), id, s.attributes[i])
+		}
+		values[s.attributes[i]] = part
+	}
+	return values, nil
+}
+
+// ImportState parses the import identifier and writes each part to its attribute.
+// String, Int64, Int32, Float64 and Bool attributes are supported; the part is converted
+// to the attribute type from the resource schema.
+func (s *ImportIDSpec) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values, err := s.Parse(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	for _, name := range s.attributes {
+		attrPath := path.Root(name)
+		attrType, diags := resp.State.Schema.TypeAtPath(ctx, attrPath)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		value, err := importValue(attrType, values[name])
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Import identifier part %s = '%s' is invalid: %v. Expected format '%s', got: '%s'", name, values[name], err, s.Format(), req.ID),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attrPath, value)...)
+	}
+}
+
+// importValue converts an import identifier part to a value of the given attribute type
+func importValue(attrType attr.Type, part string) (interface{}, error) {
+	switch {
+	case attrType.Equal(types.Int64Type):
+		return strconv.ParseInt(part, 10, 64)
+	case attrType.Equal(types.Int32Type):
+		i, err := strconv.ParseInt(part, 10, 32)
+		return int32(i), err
+	case attrType.Equal(types.Float64Type):
+		return strconv.ParseFloat(part, 64)
+	case attrType.Equal(types.BoolType):
+		return strconv.ParseBool(part)
+	default:
+		return part, nil
+	}
+}
+
+// ImportByID implements resource.ResourceWithImportState with an ImportIDSpec. Embed it next to
+// TypedBaseResource in resources that support import; resources without it do not offer import.
+type ImportByID struct {
+	spec *ImportIDSpec
+}
+
+// NewImportByID returns an ImportByID that parses identifiers with the given spec,
+// for example NewImportByID(ImportID("organization_id", "application_id"))
+func NewImportByID(spec *ImportIDSpec) ImportByID {
+	return ImportByID{
+		spec: spec,
+	}
+}
+
+// ImportState imports the resource using the import ID spec.
+// When importing by identity, each identity attribute is copied to the state attribute of the same name.
+func (i ImportByID) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		resp.Diagnostics.Append(importStateFromIdentity(ctx, req.Identity, &resp.State)...)
+		return
+	}
+	if i.spec == nil {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			"This resource has no import identifier. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	i.spec.ImportState(ctx, req, resp)
+}
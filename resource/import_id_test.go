@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testImportModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ApplicationID  types.String `tfsdk:"application_id"`
+	Port           types.Int64  `tfsdk:"port"`
+}
+
+func testImportState() tfsdk.State {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{Required: true},
+			"application_id":  schema.StringAttribute{Required: true},
+			"port":            schema.Int64Attribute{Optional: true},
+		},
+	}
+	return tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+func TestImportIDParse(t *testing.T) {
+	spec := ImportID("organization_id", "application_id")
+
+	values, err := spec.Parse("org-1/app-1")
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+	if values["organization_id"] != "org-1" || values["application_id"] != "app-1" {
+		t.Fatalf("Parse returned %v, expected org-1 and app-1", values)
+	}
+}
+
+func TestImportIDParse_Errors(t *testing.T) {
+	spec := ImportID("organization_id", "application_id").Separator(":")
+
+	tests := []struct {
+		id       string
+		contains string
+	}{
+		{id: "org-1", contains: "expected 2"},
+		{id: "org-1:app-1:extra", contains: "expected 2"},
+		{id: "org-1:", contains: "application_id is empty"},
+		{id: "", contains: "expected 2"},
+	}
+
+	for _, tt := range tests {
+		_, err := spec.Parse(tt.id)
+		if err == nil {
+			t.Fatalf("Parse(%q) should return an error", tt.id)
+		}
+		if !strings.Contains(err.Error(), tt.contains) {
+			t.Fatalf("Parse(%q) error = %q, expected it to contain %q", tt.id, err.Error(), tt.contains)
+		}
+		if !strings.Contains(err.Error(), "organization_id:application_id") {
+			t.Fatalf("Parse(%q) error = %q, expected it to contain the format", tt.id, err.Error())
+		}
+	}
+}
+
+func TestImportIDParse_SingleAttributeKeepsSeparator(t *testing.T) {
+	values, err := ImportID("name").Parse("group/with/slashes")
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+	if values["name"] != "group/with/slashes" {
+		t.Fatalf("Parse returned %v, expected 'group/with/slashes'", values["name"])
+	}
+}
+
+func TestImportIDFormatAndBuild(t *testing.T) {
+	spec := ImportID("repository", "format").Separator(":")
+
+	if spec.Format() != "repository:format" {
+		t.Fatalf("Format() = %s, expected 'repository:format'", spec.Format())
+	}
+	if spec.Build("maven-releases", "maven2") != "maven-releases:maven2" {
+		t.Fatalf("Build() = %s, expected 'maven-releases:maven2'", spec.Build("maven-releases", "maven2"))
+	}
+}
+
+func TestImportIDImportState(t *testing.T) {
+	spec := ImportID("organization_id", "application_id", "port")
+	resp := &resource.ImportStateResponse{State: testImportState()}
+
+	spec.ImportState(context.Background(), resource.ImportStateRequest{ID: "org-1/app-1/8443"}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model testImportModel
+	resp.State.Get(context.Background(), &model)
+	if model.OrganizationID.ValueString() != "org-1" {
+		t.Fatalf("organization_id = %s, expected 'org-1'", model.OrganizationID.ValueString())
+	}
+	if model.ApplicationID.ValueString() != "app-1" {
+		t.Fatalf("application_id = %s, expected 'app-1'", model.ApplicationID.ValueString())
+	}
+	if model.Port.ValueInt64() != 8443 {
+		t.Fatalf("port = %d, expected 8443", model.Port.ValueInt64())
+	}
+}
+
+func TestImportIDImportState_InvalidTypedPart(t *testing.T) {
+	spec := ImportID("organization_id", "port")
+	resp := &resource.ImportStateResponse{State: testImportState()}
+
+	spec.ImportState(context.Background(), resource.ImportStateRequest{ID: "org-1/https"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("ImportState should add an error when a part does not match the attribute type")
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "port = 'https'") {
+		t.Fatalf("Error detail = %s, expected it to name the invalid part", resp.Diagnostics.Errors()[0].Detail())
+	}
+}
+
+func TestImportByID(t *testing.T) {
+	resp := &resource.ImportStateResponse{State: testImportState()}
+
+	NewImportByID(ImportID("organization_id", "application_id")).ImportState(context.Background(), resource.ImportStateRequest{ID: "org-1/app-1"}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState returned unexpected errors: %v", resp.Diagnostics)
+	}
+	var model testImportModel
+	resp.State.Get(context.Background(), &model)
+	if model.OrganizationID.ValueString() != "org-1" || model.ApplicationID.ValueString() != "app-1" {
+		t.Fatalf("state = %v, expected org-1 and app-1", model)
+	}
+}
+
+func TestImportByID_NoSpec(t *testing.T) {
+	resp := &resource.ImportStateResponse{State: testImportState()}
+
+	ImportByID{}.ImportState(context.Background(), resource.ImportStateRequest{ID: "org-1/app-1"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("ImportState should add an error when no import ID spec is set")
+	}
+}
+
+func TestBaseResource_ImportIsOptIn(t *testing.T) {
+	type importer interface {
+		ImportState(context.Context, resource.ImportStateRequest, *resource.ImportStateResponse)
+	}
+	if _, ok := interface{}(&BaseResource{}).(importer); ok {
+		t.Fatal("BaseResource should not implement ImportState unless ImportByID is embedded")
+	}
+	r := &struct {
+		BaseResource
+		ImportByID
+	}{ImportByID: NewImportByID(ImportID("id"))}
+	if _, ok := interface{}(r).(importer); !ok {
+		t.Fatal("a resource embedding ImportByID should implement ImportState")
+	}
+}
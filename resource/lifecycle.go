@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/util"
+)
+
+// LastUpdatedAttribute is the attribute set to the current timestamp after Create and Update
+const LastUpdatedAttribute = "last_updated"
+
+// Lifecycle describes the API operations of a resource.
+// M is the Terraform model and O the API object returned by the client.
+// Implementations only talk to the API; LifecycleResource handles the plan and state plumbing.
+type Lifecycle[M any, O any] interface {
+	// CreateObject creates the object described by the plan
+	CreateObject(ctx context.Context, plan *M) (*O, *http.Response, error)
+
+	// ReadObject reads the object described by the state.
+	// Returning a nil object without an error is treated as not found.
+	ReadObject(ctx context.Context, state *M) (*O, *http.Response, error)
+
+	// UpdateObject updates the object from the plan, with the prior state for reference
+	UpdateObject(ctx context.Context, plan *M, state *M) (*O, *http.Response, error)
+
+	// DeleteObject deletes the object described by the state
+	DeleteObject(ctx context.Context, state *M) (*http.Response, error)
+
+	// MapToModel copies the API object onto the model. The object may be nil when the API returns no body.
+	MapToModel(ctx context.Context, object *O, model *M) diag.Diagnostics
+}
+
+// LifecycleResource implements Create, Read, Update and Delete on top of TypedBaseResource
+// by delegating the API calls to a Lifecycle. Embed it in a resource and provide Metadata and Schema.
+type LifecycleResource[C any, A any, M any, O any] struct {
+	TypedBaseResource[C, A]
+	resourceType string
+	lifecycle    Lifecycle[M, O]
+	fieldPaths   errors.FieldPaths
+}
+
+// NewLifecycleResource creates a new LifecycleResource. The resource type is used in diagnostics,
+// for example "application" produces "Error creating application".
+func NewLifecycleResource[C any, A any, M any, O any](resourceType string, lifecycle Lifecycle[M, O]) LifecycleResource[C, A, M, O] {
+	return LifecycleResource[C, A, M, O]{
+		resourceType: resourceType,
+		lifecycle:    lifecycle,
+	}
+}
+
+// SetFieldPaths maps API field names to attributes so validation errors are reported on the offending argument
+func (r *LifecycleResource[C, A, M, O]) SetFieldPaths(fields errors.FieldPaths) {
+	r.fieldPaths = fields
+}
+
+// Create reads the plan, creates the object and stores the mapped result in state
+func (r *LifecycleResource[C, A, M, O]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.Plan.Schema.GetAttributes()), req.Plan, schema.TimeoutCreate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	object, httpResponse, err := r.lifecycle.CreateObject(ctx, &plan)
+	if err != nil {
+		r.handleAPIError(ctx, schema.TimeoutCreate, err, httpResponse, &resp.Diagnostics)
+		return
+	}
+
+	r.saveState(ctx, object, &plan, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Read refreshes the state from the API, removing the resource from state when it no longer exists
+func (r *LifecycleResource[C, A, M, O]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state M
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.State.Schema.GetAttributes()), req.State, schema.TimeoutRead)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	object, httpResponse, err := r.lifecycle.ReadObject(ctx, &state)
+	if isNotFoundResponse(httpResponse, err) || (err == nil && object == nil) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		r.handleAPIError(ctx, schema.TimeoutRead, err, httpResponse, &resp.Diagnostics)
+		return
+	}
+
+	resp.Diagnostics.Append(r.lifecycle.MapToModel(ctx, object, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(SetIdentityFromState(ctx, resp.State, resp.Identity)...)
+}
+
+// Update reads the plan and prior state, updates the object and stores the mapped result in state
+func (r *LifecycleResource[C, A, M, O]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.Plan.Schema.GetAttributes()), req.Plan, schema.TimeoutUpdate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	object, httpResponse, err := r.lifecycle.UpdateObject(ctx, &plan, &state)
+	if err != nil {
+		r.handleAPIError(ctx, schema.TimeoutUpdate, err, httpResponse, &resp.Diagnostics)
+		return
+	}
+
+	r.saveState(ctx, object, &plan, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Delete deletes the object. An object that is already gone is not an error.
+func (r *LifecycleResource[C, A, M, O]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state M
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.State.Schema.GetAttributes()), req.State, schema.TimeoutDelete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResponse, err := r.lifecycle.DeleteObject(ctx, &state)
+	if err != nil && !isNotFoundResponse(httpResponse, err) {
+		r.handleAPIError(ctx, schema.TimeoutDelete, err, httpResponse, &resp.Diagnostics)
+	}
+}
+
+// saveState maps the API object onto the model, stores it in state, sets last_updated and fills the identity
+func (r *LifecycleResource[C, A, M, O]) saveState(ctx context.Context, object *O, model *M, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	diags.Append(r.lifecycle.MapToModel(ctx, object, model)...)
+	if diags.HasError() {
+		return
+	}
+
+	diags.Append(state.Set(ctx, model)...)
+	if diags.HasError() {
+		return
+	}
+	finishSavedState(ctx, state, identity, diags)
+}
+
+// finishSavedState sets last_updated when the schema has it and fills the identity from the saved state
+func finishSavedState(ctx context.Context, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	if _, ok := state.Schema.GetAttributes()[LastUpdatedAttribute]; ok {
+		diags.Append(state.SetAttribute(ctx, path.Root(LastUpdatedAttribute), util.CurrentTimestamp())...)
+	}
+	if diags.HasError() {
+		return
+	}
+	diags.Append(SetIdentityFromState(ctx, *state, identity)...)
+}
+
+// handleAPIError adds a standardized diagnostic for a failed API call, reporting a hit deadline as a timeout
+func (r *LifecycleResource[C, A, M, O]) handleAPIError(ctx context.Context, operation string, err error, httpResponse *http.Response, diags *diag.Diagnostics) {
+	handleOperationError(ctx, operation, r.resourceType, r.fieldPaths, err, httpResponse, diags)
+}
+
+// handleOperationError adds a standardized diagnostic for a failed API call, reporting a hit deadline as a timeout
+func handleOperationError(ctx context.Context, operation string, resourceType string, fieldPaths errors.FieldPaths, err error, httpResponse *http.Response, diags *diag.Diagnostics) {
+	if AddDeadlineDiagnostic(ctx, diags, operation, resourceType) {
+		return
+	}
+	title, _ := errors.APIErrorMessage(operationVerb(operation), resourceType, "")
+	errors.HandleAPIErrorWithFields(title, &err, httpResponse, fieldPaths, diags)
+}
+
+// isNotFoundResponse checks if the HTTP response, or a ResponseError returned by the lifecycle, is a 404
+func isNotFoundResponse(httpResponse *http.Response, err error) bool {
+	if httpResponse != nil {
+		return errors.IsNotFound(httpResponse.StatusCode)
+	}
+	return errors.IsNotFound(errors.StatusCode(err))
+}
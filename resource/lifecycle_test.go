@@ -0,0 +1,299 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	sharederrors "github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+type testModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type testObject struct {
+	ID   string
+	Name string
+}
+
+type testLifecycle struct {
+	object       *testObject
+	httpResponse *http.Response
+	err          error
+	deleted      bool
+}
+
+func (l *testLifecycle) CreateObject(ctx context.Context, plan *testModel) (*testObject, *http.Response, error) {
+	return l.object, l.httpResponse, l.err
+}
+
+func (l *testLifecycle) ReadObject(ctx context.Context, state *testModel) (*testObject, *http.Response, error) {
+	return l.object, l.httpResponse, l.err
+}
+
+func (l *testLifecycle) UpdateObject(ctx context.Context, plan *testModel, state *testModel) (*testObject, *http.Response, error) {
+	return l.object, l.httpResponse, l.err
+}
+
+func (l *testLifecycle) DeleteObject(ctx context.Context, state *testModel) (*http.Response, error) {
+	l.deleted = true
+	return l.httpResponse, l.err
+}
+
+func (l *testLifecycle) MapToModel(ctx context.Context, object *testObject, model *testModel) diag.Diagnostics {
+	model.ID = types.StringValue(object.ID)
+	model.Name = types.StringValue(object.Name)
+	return nil
+}
+
+func testLifecycleSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true},
+			"name":         schema.StringAttribute{Required: true},
+			"last_updated": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func testLifecycleRaw(id interface{}, name string) tftypes.Value {
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":           tftypes.String,
+		"name":         tftypes.String,
+		"last_updated": tftypes.String,
+	}}
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, id),
+		"name":         tftypes.NewValue(tftypes.String, name),
+		"last_updated": tftypes.NewValue(tftypes.String, nil),
+	})
+}
+
+func newTestLifecycleResource(l *testLifecycle) *LifecycleResource[interface{}, interface{}, testModel, testObject] {
+	r := NewLifecycleResource[interface{}, interface{}, testModel, testObject]("widget", l)
+	return &r
+}
+
+func TestLifecycleCreate(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{object: &testObject{ID: "w-1", Name: "widget"}})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "widget")}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var state testModel
+	resp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "w-1" {
+		t.Fatalf("state id = %s, expected 'w-1'", state.ID.ValueString())
+	}
+	if state.LastUpdated.IsNull() || state.LastUpdated.ValueString() == "" {
+		t.Fatal("Create should set last_updated")
+	}
+}
+
+func TestLifecycleCreate_APIError(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{
+		err:          errors.New("boom"),
+		httpResponse: &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "widget")}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Create should add an error when the API call fails")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Error creating widget" {
+		t.Fatalf("Error summary = %s, expected 'Error creating widget'", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
+
+func TestLifecycleCreate_FieldErrors(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{
+		err: &sharederrors.ResponseError{
+			StatusCode: http.StatusBadRequest,
+			Status:     "400 Bad Request",
+			Body:       []byte(`[{"id":"PARAMETER name","message":"Name is already used"}]`),
+		},
+	})
+	r.SetFieldPaths(sharederrors.FieldPaths{"name": path.Root("name")})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "widget")}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Fatalf("expected an attribute error on name, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestLifecycleRead_NotFoundRemovesResource(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{
+		err:          errors.New("not found"),
+		httpResponse: &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+	})
+
+	raw := testLifecycleRaw("w-1", "widget")
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read returned unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Fatal("Read should remove the resource from state on 404")
+	}
+}
+
+func TestLifecycleRead_NotFoundAPIErrorRemovesResource(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{
+		err: &sharederrors.ResponseError{StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+	})
+
+	raw := testLifecycleRaw("w-1", "widget")
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read returned unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Fatal("Read should remove the resource from state when the ResponseError is a 404")
+	}
+}
+
+func TestLifecycleRead_RefreshesState(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{object: &testObject{ID: "w-1", Name: "renamed"}})
+
+	raw := testLifecycleRaw("w-1", "widget")
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+
+	r.Read(context.Background(), req, resp)
+
+	var state testModel
+	resp.State.Get(context.Background(), &state)
+	if state.Name.ValueString() != "renamed" {
+		t.Fatalf("state name = %s, expected 'renamed'", state.Name.ValueString())
+	}
+	if !state.LastUpdated.IsNull() {
+		t.Fatal("Read should not change last_updated")
+	}
+}
+
+func TestLifecycleUpdate(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{object: &testObject{ID: "w-1", Name: "updated"}})
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: testLifecycleRaw("w-1", "updated")},
+		State: tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")}}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var state testModel
+	resp.State.Get(context.Background(), &state)
+	if state.Name.ValueString() != "updated" {
+		t.Fatalf("state name = %s, expected 'updated'", state.Name.ValueString())
+	}
+	if state.LastUpdated.IsNull() {
+		t.Fatal("Update should set last_updated")
+	}
+}
+
+func TestLifecycleDelete_NotFoundIsNotAnError(t *testing.T) {
+	s := testLifecycleSchema()
+	l := &testLifecycle{
+		err:          errors.New("not found"),
+		httpResponse: &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+	}
+	r := newTestLifecycleResource(l)
+
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")}}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !l.deleted {
+		t.Fatal("Delete should call DeleteObject")
+	}
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete returned unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestLifecycleDelete_APIError(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestLifecycleResource(&testLifecycle{
+		err:          errors.New("boom"),
+		httpResponse: &http.Response{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error"},
+	})
+
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: testLifecycleRaw("w-1", "widget")}}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Delete should add an error when the API call fails")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Error deleting widget" {
+		t.Fatalf("Error summary = %s, expected 'Error deleting widget'", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
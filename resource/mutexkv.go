@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+// MutexKV is a set of locks identified by key, used to serialize writes to server objects that
+// several resources modify, such as the security realms or a group repository's members
+type MutexKV struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is a lock that can be acquired with a context, counting its holders and waiters so it
+// can be removed once unused
+type keyedLock struct {
+	held chan struct{}
+	refs int
+}
+
+// NewMutexKV creates an empty set of locks
+func NewMutexKV() *MutexKV {
+	return &MutexKV{locks: map[string]*keyedLock{}}
+}
+
+// defaultMutexKV is shared by resources whose provider does not supply its own locks. A provider
+// plugin serves a single provider, so it is provider scoped in practice.
+var defaultMutexKV = NewMutexKV()
+
+// MutexKVProvider is implemented by providers that share their own locks with resources
+type MutexKVProvider interface {
+	GetLocks() *MutexKV
+}
+
+// Lock acquires the locks for every key, waiting until they are free or ctx is done. Keys are
+// acquired in sorted order so that callers locking overlapping keys cannot deadlock. The returned
+// function releases all of them and may be called more than once.
+func (m *MutexKV) Lock(ctx context.Context, keys ...string) (func(), error) {
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	acquired := make([]string, 0, len(keys))
+	release := func() {
+		for i := len(acquired) - 1; i >= 0; i-- {
+			m.unlock(acquired[i])
+		}
+	}
+
+	for _, key := range keys {
+		if err := m.lock(ctx, key); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, key)
+	}
+
+	var once sync.Once
+	return func() { once.Do(release) }, nil
+}
+
+// lock acquires a single key, logging when it has to wait
+func (m *MutexKV) lock(ctx context.Context, key string) error {
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &keyedLock{held: make(chan struct{}, 1)}
+		m.locks[key] = lock
+	}
+	lock.refs++
+	m.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	tflog.Debug(ctx, "Waiting for lock", map[string]interface{}{"key": key})
+	select {
+	case lock.held <- struct{}{}:
+		tflog.Debug(ctx, "Acquired lock", map[string]interface{}{"key": key, "waited": time.Since(start).String()})
+		return nil
+	case <-ctx.Done():
+		m.release(key, lock)
+		tflog.Debug(ctx, "Gave up waiting for lock", map[string]interface{}{"key": key, "waited": time.Since(start).String()})
+		return fmt.Errorf("waiting for lock on %q: %w", key, ctx.Err())
+	}
+}
+
+// unlock releases a held key
+func (m *MutexKV) unlock(key string) {
+	m.mu.Lock()
+	lock := m.locks[key]
+	m.mu.Unlock()
+	<-lock.held
+	m.release(key, lock)
+}
+
+// release drops a reference to the lock, removing it once nobody holds or waits for it
+func (m *MutexKV) release(key string, lock *keyedLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(m.locks, key)
+	}
+}
+
+// GetLocks returns the provider's locks, or the locks shared by the whole plugin when none are set
+func (c *TypedBaseResourceConfig[C, A]) GetLocks() *MutexKV {
+	if c == nil || c.Locks == nil {
+		return defaultMutexKV
+	}
+	return c.Locks
+}
+
+// GetLocks returns the locks shared with the other resources of the provider
+func (r *TypedBaseResource[C, A]) GetLocks() *MutexKV {
+	return r.config.GetLocks()
+}
+
+// Lock acquires the provider scoped locks for the keys, e.g. "security/realms", and adds an error
+// when ctx ends first. Use it around read-modify-write sequences on shared server objects:
+//
+//	unlock, ok := r.Lock(ctx, &resp.Diagnostics, "role/"+roleID)
+//	if !ok {
+//		return
+//	}
+//	defer unlock()
+func (r *TypedBaseResource[C, A]) Lock(ctx context.Context, diags *diag.Diagnostics, keys ...string) (func(), bool) {
+	unlock, err := r.GetLocks().Lock(ctx, keys...)
+	if err == nil {
+		return unlock, true
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		errors.AddErrorWithCode(
+			diags,
+			errors.CodeTimeout,
+			"Timed Out Waiting for Lock",
+			fmt.Sprintf("Another operation in this apply kept a lock on the same server object for too long (%v). Increase the timeout or apply with a lower -parallelism.", err),
+		)
+		return nil, false
+	}
+	AddErrorf(diags, "Lock Not Acquired", "Waiting for a lock on a shared server object was cancelled: %v", err)
+	return nil, false
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestMutexKV_SerializesSameKey(t *testing.T) {
+	locks := NewMutexKV()
+	members := []string{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.Lock(context.Background(), "group/maven-public")
+			if err != nil {
+				t.Errorf("Lock returned unexpected error: %v", err)
+				return
+			}
+			defer unlock()
+			current := members
+			time.Sleep(time.Millisecond)
+			members = append(current, "member")
+		}()
+	}
+	wg.Wait()
+
+	if len(members) != 20 {
+		t.Fatalf("len(members) = %d, expected 20 writes without lost updates", len(members))
+	}
+	if len(locks.locks) != 0 {
+		t.Fatalf("%d locks left behind, expected unused locks to be removed", len(locks.locks))
+	}
+}
+
+func TestMutexKV_IndependentKeys(t *testing.T) {
+	locks := NewMutexKV()
+	unlock, err := locks.Lock(context.Background(), "security/realms")
+	if err != nil {
+		t.Fatalf("Lock returned unexpected error: %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	other, err := locks.Lock(ctx, "role/nx-admin")
+	if err != nil {
+		t.Fatalf("a different key should not wait: %v", err)
+	}
+	other()
+}
+
+func TestMutexKV_ContextCancellation(t *testing.T) {
+	locks := NewMutexKV()
+	unlock, _ := locks.Lock(context.Background(), "security/realms")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(ctx, "security/realms"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock() error = %v, expected a deadline error", err)
+	}
+
+	unlock()
+	unlock()
+	again, err := locks.Lock(context.Background(), "security/realms")
+	if err != nil {
+		t.Fatalf("Lock after release returned unexpected error: %v", err)
+	}
+	again()
+	if len(locks.locks) != 0 {
+		t.Fatalf("%d locks left behind, expected unused locks to be removed", len(locks.locks))
+	}
+}
+
+func TestMutexKV_MultipleKeysDoNotDeadlock(t *testing.T) {
+	locks := NewMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		keys := []string{"role/a", "role/b", "role/a"}
+		if i%2 == 0 {
+			keys = []string{"role/b", "role/a"}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.Lock(ctx, keys...)
+			if err != nil {
+				t.Errorf("Lock(%v) returned unexpected error: %v", keys, err)
+				return
+			}
+			unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMutexKV_ReleasesPartialAcquisition(t *testing.T) {
+	locks := NewMutexKV()
+	unlockB, _ := locks.Lock(context.Background(), "b")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(ctx, "a", "b"); err == nil {
+		t.Fatal("Lock should fail while b is held")
+	}
+
+	unlockA, err := locks.Lock(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("a should have been released after the failed acquisition: %v", err)
+	}
+	unlockA()
+	unlockB()
+}
+
+func TestBaseResource_Lock(t *testing.T) {
+	locks := NewMutexKV()
+	first := NewBaseResource(nil)
+	first.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &BaseResourceConfig{Client: "client", Locks: locks}}, &resource.ConfigureResponse{})
+	second := NewBaseResource(nil)
+	second.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &BaseResourceConfig{Client: "client", Locks: locks}}, &resource.ConfigureResponse{})
+	if first.GetLocks() != locks || second.GetLocks() != locks {
+		t.Fatal("resources should share the provider's locks")
+	}
+
+	var diags diag.Diagnostics
+	unlock, ok := first.Lock(context.Background(), &diags, "security/realms")
+	if !ok {
+		t.Fatalf("Lock returned unexpected errors: %v", diags)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, ok := second.Lock(ctx, &diags, "security/realms"); ok {
+		t.Fatal("Lock should fail while another resource holds the key")
+	}
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Timed Out Waiting for Lock" || !strings.Contains(diags.Errors()[0].Detail(), "security/realms") {
+		t.Fatalf("diagnostics = %v, expected a lock timeout naming the key", diags)
+	}
+
+	if NewBaseResource(nil).GetLocks() != defaultMutexKV {
+		t.Fatal("an unconfigured resource should use the plugin wide locks")
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+// IDAttribute is the attribute holding a resource's identifier
+const IDAttribute = "id"
+
+// Singleton describes the API operations of a settings object that always exists on the server,
+// such as the email or HTTP proxy configuration. M is the Terraform model and O the API object.
+type Singleton[M any, O any] interface {
+	// ReadObject reads the current settings.
+	// Returning a nil object without an error is treated as not configured.
+	ReadObject(ctx context.Context) (*O, *http.Response, error)
+
+	// WriteObject replaces the settings with the plan. It is used for both Create and Update.
+	WriteObject(ctx context.Context, plan *M) (*O, *http.Response, error)
+
+	// MapToModel copies the API object onto the model. The object may be nil when the API returns no body.
+	MapToModel(ctx context.Context, object *O, model *M) diag.Diagnostics
+}
+
+// ResetFunc restores server defaults, or detaches the server, when a singleton resource is destroyed
+type ResetFunc[M any] func(ctx context.Context, state *M) (*http.Response, error)
+
+// ResetToDefaults returns a ResetFunc that writes the given default settings
+func ResetToDefaults[M any, O any](singleton Singleton[M, O], defaults M) ResetFunc[M] {
+	return func(ctx context.Context, _ *M) (*http.Response, error) {
+		model := defaults
+		_, httpResponse, err := singleton.WriteObject(ctx, &model)
+		return httpResponse, err
+	}
+}
+
+// SingletonResource implements Create, Read, Update, Delete and ImportState for singleton settings
+// on top of TypedBaseResource. Create and Update write the settings, the id attribute always holds
+// the same value, any import identifier is accepted and Delete runs the reset function, if any.
+// Writes are serialized with the provider's locks. Embed it in a resource and provide Metadata and Schema.
+type SingletonResource[C any, A any, M any, O any] struct {
+	TypedBaseResource[C, A]
+	resourceType string
+	id           string
+	singleton    Singleton[M, O]
+	reset        ResetFunc[M]
+	fieldPaths   errors.FieldPaths
+}
+
+// NewSingletonResource creates a new SingletonResource. The resource type is used in diagnostics,
+// for example "email configuration" produces "Error updating email configuration", and id is stored
+// in the id attribute.
+func NewSingletonResource[C any, A any, M any, O any](resourceType string, id string, singleton Singleton[M, O]) SingletonResource[C, A, M, O] {
+	return SingletonResource[C, A, M, O]{
+		resourceType: resourceType,
+		id:           id,
+		singleton:    singleton,
+	}
+}
+
+// SetReset sets the function run on destroy. Without one, destroying only removes the resource from state.
+func (r *SingletonResource[C, A, M, O]) SetReset(reset ResetFunc[M]) {
+	r.reset = reset
+}
+
+// SetFieldPaths maps API field names to attributes so validation errors are reported on the offending argument
+func (r *SingletonResource[C, A, M, O]) SetFieldPaths(fields errors.FieldPaths) {
+	r.fieldPaths = fields
+}
+
+// ID returns the fixed identifier of the resource
+func (r *SingletonResource[C, A, M, O]) ID() string {
+	return r.id
+}
+
+// Create writes the settings from the plan
+func (r *SingletonResource[C, A, M, O]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.write(ctx, req.Plan, schema.TimeoutCreate, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Read refreshes the state from the API, removing the resource from state when the settings are not configured
+func (r *SingletonResource[C, A, M, O]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state M
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.State.Schema.GetAttributes()), req.State, schema.TimeoutRead)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	object, httpResponse, err := r.singleton.ReadObject(ctx)
+	if isNotFoundResponse(httpResponse, err) || (err == nil && object == nil) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		handleOperationError(ctx, schema.TimeoutRead, r.resourceType, r.fieldPaths, err, httpResponse, &resp.Diagnostics)
+		return
+	}
+
+	resp.Diagnostics.Append(r.singleton.MapToModel(ctx, object, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.setID(ctx, &resp.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(SetIdentityFromState(ctx, resp.State, resp.Identity)...)
+}
+
+// Update writes the settings from the plan, exactly like Create
+func (r *SingletonResource[C, A, M, O]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.write(ctx, req.Plan, schema.TimeoutUpdate, &resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Delete runs the reset function. Settings that are already gone are not an error.
+func (r *SingletonResource[C, A, M, O]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.reset == nil {
+		tflog.Info(ctx, "Removing singleton from state without changing the server", map[string]interface{}{"resource": r.resourceType})
+		return
+	}
+
+	var state M
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := operationContext(ctx, hasTimeouts(req.State.Schema.GetAttributes()), req.State, schema.TimeoutDelete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock, ok := r.Lock(ctx, &resp.Diagnostics, r.lockKey())
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	httpResponse, err := r.reset(ctx, &state)
+	if err != nil && !isNotFoundResponse(httpResponse, err) {
+		handleOperationError(ctx, schema.TimeoutDelete, r.resourceType, r.fieldPaths, err, httpResponse, &resp.Diagnostics)
+	}
+}
+
+// ImportState accepts any identifier, since there is only one instance, and sets the fixed id.
+// Read then fills in the settings.
+func (r *SingletonResource[C, A, M, O]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" && req.ID != r.id {
+		tflog.Debug(ctx, "Importing singleton with its fixed identifier", map[string]interface{}{"import_id": req.ID, "id": r.id})
+	}
+	r.setID(ctx, &resp.State, &resp.Diagnostics)
+}
+
+// write locks the settings, writes the plan and stores the mapped result in state
+func (r *SingletonResource[C, A, M, O]) write(ctx context.Context, planData tfsdk.Plan, operation string, state *tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	var plan M
+	diags.Append(planData.Get(ctx, &plan)...)
+	if diags.HasError() {
+		return
+	}
+
+	ctx, cancel, timeoutDiags := operationContext(ctx, hasTimeouts(planData.Schema.GetAttributes()), planData, operation)
+	defer cancel()
+	diags.Append(timeoutDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	unlock, ok := r.Lock(ctx, diags, r.lockKey())
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	object, httpResponse, err := r.singleton.WriteObject(ctx, &plan)
+	if err != nil {
+		handleOperationError(ctx, operation, r.resourceType, r.fieldPaths, err, httpResponse, diags)
+		return
+	}
+
+	diags.Append(r.singleton.MapToModel(ctx, object, &plan)...)
+	if diags.HasError() {
+		return
+	}
+	diags.Append(state.Set(ctx, &plan)...)
+	if diags.HasError() {
+		return
+	}
+	r.setID(ctx, state, diags)
+	if diags.HasError() {
+		return
+	}
+	finishSavedState(ctx, state, identity, diags)
+}
+
+// setID stores the fixed identifier when the schema has an id attribute
+func (r *SingletonResource[C, A, M, O]) setID(ctx context.Context, state *tfsdk.State, diags *diag.Diagnostics) {
+	if _, ok := state.Schema.GetAttributes()[IDAttribute]; ok {
+		diags.Append(state.SetAttribute(ctx, path.Root(IDAttribute), r.id)...)
+	}
+}
+
+// lockKey serializes writes from every resource managing the same settings
+func (r *SingletonResource[C, A, M, O]) lockKey() string {
+	return "singleton/" + r.id
+}
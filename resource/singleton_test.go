@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testSingleton struct {
+	object       *testObject
+	httpResponse *http.Response
+	err          error
+	writes       []string
+	onWrite      func(ctx context.Context)
+}
+
+func (s *testSingleton) ReadObject(ctx context.Context) (*testObject, *http.Response, error) {
+	return s.object, s.httpResponse, s.err
+}
+
+func (s *testSingleton) WriteObject(ctx context.Context, plan *testModel) (*testObject, *http.Response, error) {
+	if s.onWrite != nil {
+		s.onWrite(ctx)
+	}
+	s.writes = append(s.writes, plan.Name.ValueString())
+	if s.err != nil {
+		return nil, s.httpResponse, s.err
+	}
+	return &testObject{Name: plan.Name.ValueString()}, s.httpResponse, nil
+}
+
+func (s *testSingleton) MapToModel(ctx context.Context, object *testObject, model *testModel) diag.Diagnostics {
+	model.Name = types.StringValue(object.Name)
+	return nil
+}
+
+func newTestSingletonResource(s *testSingleton) *SingletonResource[interface{}, interface{}, testModel, testObject] {
+	r := NewSingletonResource[interface{}, interface{}, testModel, testObject]("email configuration", "email", s)
+	return &r
+}
+
+func TestSingletonCreateAndUpdateWrite(t *testing.T) {
+	s := testLifecycleSchema()
+	singleton := &testSingleton{}
+	r := newTestSingletonResource(singleton)
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "smtp.example.com")}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create returned unexpected errors: %v", createResp.Diagnostics)
+	}
+
+	var state testModel
+	createResp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "email" || state.LastUpdated.IsNull() {
+		t.Fatalf("state = %+v, expected the fixed id and last_updated", state)
+	}
+
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: testLifecycleRaw("email", "mail.example.com")},
+		State: createResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update returned unexpected errors: %v", updateResp.Diagnostics)
+	}
+
+	updateResp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "email" || state.Name.ValueString() != "mail.example.com" {
+		t.Fatalf("state = %+v, expected the updated settings under the fixed id", state)
+	}
+	if len(singleton.writes) != 2 || singleton.writes[1] != "mail.example.com" {
+		t.Fatalf("writes = %v, expected Create and Update to write the settings", singleton.writes)
+	}
+}
+
+func TestSingletonWrite_HoldsLock(t *testing.T) {
+	s := testLifecycleSchema()
+	singleton := &testSingleton{}
+	r := newTestSingletonResource(singleton)
+	r.config = &TypedBaseResourceConfig[interface{}, interface{}]{Client: "client", Locks: NewMutexKV()}
+	singleton.onWrite = func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := r.GetLocks().Lock(ctx, "singleton/email"); err == nil {
+			t.Error("WriteObject should run while the singleton is locked")
+		}
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "smtp.example.com")}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create returned unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestSingletonCreate_APIError(t *testing.T) {
+	s := testLifecycleSchema()
+	r := newTestSingletonResource(&testSingleton{
+		err:          errors.New("boom"),
+		httpResponse: &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: testLifecycleRaw(tftypes.UnknownValue, "smtp.example.com")}}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error creating email configuration" {
+		t.Fatalf("diagnostics = %v, expected 'Error creating email configuration'", resp.Diagnostics)
+	}
+}
+
+func TestSingletonRead(t *testing.T) {
+	s := testLifecycleSchema()
+	raw := testLifecycleRaw("email", "smtp.example.com")
+
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	newTestSingletonResource(&testSingleton{object: &testObject{Name: "changed.example.com"}}).
+		Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}, resp)
+	var state testModel
+	resp.State.Get(context.Background(), &state)
+	if resp.Diagnostics.HasError() || state.Name.ValueString() != "changed.example.com" || state.ID.ValueString() != "email" {
+		t.Fatalf("state = %+v, %v, expected the refreshed settings", state, resp.Diagnostics)
+	}
+
+	resp = &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	newTestSingletonResource(&testSingleton{}).Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}, resp)
+	if resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+		t.Fatal("Read should remove settings that are not configured from state")
+	}
+}
+
+func TestSingletonImportState_AcceptsAnyID(t *testing.T) {
+	s := testLifecycleSchema()
+	for _, id := range []string{"email", "anything", ""} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+		newTestSingletonResource(&testSingleton{}).ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+
+		var state testModel
+		resp.State.Get(context.Background(), &state)
+		if resp.Diagnostics.HasError() || state.ID.ValueString() != "email" {
+			t.Fatalf("ImportState(%q) = %+v, %v, expected the fixed id", id, state, resp.Diagnostics)
+		}
+	}
+}
+
+func TestSingletonDelete(t *testing.T) {
+	s := testLifecycleSchema()
+	raw := testLifecycleRaw("email", "smtp.example.com")
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: raw}}
+
+	singleton := &testSingleton{}
+	r := newTestSingletonResource(singleton)
+	resp := &resource.DeleteResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	r.Delete(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() || len(singleton.writes) != 0 {
+		t.Fatalf("Delete without a reset function wrote %v, %v, expected no API calls", singleton.writes, resp.Diagnostics)
+	}
+
+	r.SetReset(ResetToDefaults[testModel, testObject](singleton, testModel{Name: types.StringValue("localhost")}))
+	r.Delete(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() || len(singleton.writes) != 1 || singleton.writes[0] != "localhost" {
+		t.Fatalf("Delete wrote %v, %v, expected the defaults", singleton.writes, resp.Diagnostics)
+	}
+}
+
+func TestSingletonDelete_ResetErrors(t *testing.T) {
+	s := testLifecycleSchema()
+	raw := testLifecycleRaw("email", "smtp.example.com")
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: raw}}
+	reset := func(status int) ResetFunc[testModel] {
+		return func(ctx context.Context, state *testModel) (*http.Response, error) {
+			if state.Name.ValueString() != "smtp.example.com" {
+				t.Errorf("reset received state %+v, expected the prior state", state)
+			}
+			return &http.Response{StatusCode: status, Status: http.StatusText(status)}, errors.New("reset failed")
+		}
+	}
+
+	r := newTestSingletonResource(&testSingleton{})
+	r.SetReset(reset(http.StatusNotFound))
+	resp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete returned unexpected errors for a detached singleton: %v", resp.Diagnostics)
+	}
+
+	r.SetReset(reset(http.StatusInternalServerError))
+	resp = &resource.DeleteResponse{}
+	r.Delete(context.Background(), req, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error deleting email configuration" {
+		t.Fatalf("diagnostics = %v, expected 'Error deleting email configuration'", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// StateUpgradeStep upgrades state from one schema version to the next.
+// Create steps with StateUpgrade and chain them with NewStateUpgradeRegistry.
+type StateUpgradeStep struct {
+	version     int64
+	priorSchema resourceschema.Schema
+	priorType   reflect.Type
+	nextType    reflect.Type
+	read        func(ctx context.Context, state tfsdk.State) (interface{}, diag.Diagnostics)
+	upgrade     func(ctx context.Context, prior interface{}) (interface{}, diag.Diagnostics)
+}
+
+// StateUpgrade creates a step that upgrades state from version to version+1.
+// P is the model for the prior schema and N the model for the next version; N must be the P of the following step.
+func StateUpgrade[P any, N any](version int64, priorSchema resourceschema.Schema, upgrade func(ctx context.Context, prior P) (N, diag.Diagnostics)) StateUpgradeStep {
+	return StateUpgradeStep{
+		version:     version,
+		priorSchema: priorSchema,
+		priorType:   reflect.TypeFor[P](),
+		nextType:    reflect.TypeFor[N](),
+		read: func(ctx context.Context, state tfsdk.State) (interface{}, diag.Diagnostics) {
+			var prior P
+			diags := state.Get(ctx, &prior)
+			return prior, diags
+		},
+		upgrade: func(ctx context.Context, prior interface{}) (interface{}, diag.Diagnostics) {
+			return upgrade(ctx, prior.(P))
+		},
+	}
+}
+
+// StateUpgradeRegistry chains state upgrade steps so state from any prior version
+// is upgraded step by step to the current schema version
+type StateUpgradeRegistry struct {
+	steps []StateUpgradeStep
+}
+
+// NewStateUpgradeRegistry creates a registry from the given steps, in any order
+func NewStateUpgradeRegistry(steps ...StateUpgradeStep) *StateUpgradeRegistry {
+	sorted := append([]StateUpgradeStep(nil), steps...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].version < sorted[j].version
+	})
+	return &StateUpgradeRegistry{
+		steps: sorted,
+	}
+}
+
+// CurrentVersion returns the schema version the registry upgrades to, for use as the resource schema Version
+func (r *StateUpgradeRegistry) CurrentVersion() int64 {
+	if len(r.steps) == 0 {
+		return 0
+	}
+	return r.steps[len(r.steps)-1].version + 1
+}
+
+// Validate checks that the steps have consecutive versions and that each step's
+// next model matches the prior model of the following step
+func (r *StateUpgradeRegistry) Validate() error {
+	for i := 1; i < len(r.steps); i++ {
+		prev, step := r.steps[i-1], r.steps[i]
+		if step.version != prev.version+1 {
+			return fmt.Errorf("state upgrade steps must have consecutive versions, got %d after %d", step.version, prev.version)
+		}
+		if prev.nextType != step.priorType {
+			return fmt.Errorf("state upgrade from version %d returns %s but the upgrade from version %d expects %s",
+				prev.version, prev.nextType, step.version, step.priorType)
+		}
+	}
+	return nil
+}
+
+// UpgradeState returns the upgraders for the resource UpgradeState method.
+// Each upgrader runs every step from its prior version to the current version.
+func (r *StateUpgradeRegistry) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(r.steps))
+	for i := range r.steps {
+		upgraders[r.steps[i].version] = resource.StateUpgrader{
+			PriorSchema: &r.steps[i].priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				r.upgradeFrom(ctx, i, req, resp)
+			},
+		}
+	}
+	return upgraders
+}
+
+// upgradeFrom runs the steps starting at the given index and stores the result in the response state
+func (r *StateUpgradeRegistry) upgradeFrom(ctx context.Context, index int, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if err := r.Validate(); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid State Upgrade Chain",
+			fmt.Sprintf("%v. Please report this issue to the provider developers.", err),
+		)
+		return
+	}
+	if req.State == nil {
+		resp.Diagnostics.AddError(
+			"Missing Prior State",
+			fmt.Sprintf("No prior state was provided to upgrade from version %d. Please report this issue to the provider developers.", r.steps[index].version),
+		)
+		return
+	}
+
+	value, diags := r.steps[index].read(ctx, *req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, step := range r.steps[index:] {
+		value, diags = step.upgrade(ctx, value)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, value)...)
+}
+
+// UpgradeRawState feeds recorded raw state JSON from the given schema version through the registry
+// and returns the upgraded state for the current schema. It is intended for unit tests.
+func UpgradeRawState(ctx context.Context, registry *StateUpgradeRegistry, currentSchema resourceschema.Schema, version int64, rawJSON []byte) (tfsdk.State, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := tfsdk.State{
+		Schema: currentSchema,
+		Raw:    tftypes.NewValue(currentSchema.Type().TerraformType(ctx), nil),
+	}
+
+	upgrader, ok := registry.UpgradeState(ctx)[version]
+	if !ok {
+		diags.AddError("Unsupported State Version", fmt.Sprintf("No state upgrade is registered for version %d", version))
+		return state, diags
+	}
+
+	rawState := tfprotov6.RawState{JSON: rawJSON}
+	priorValue, err := rawState.UnmarshalWithOpts(upgrader.PriorSchema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		diags.AddError("Invalid Raw State", fmt.Sprintf("Could not parse raw state for version %d: %v", version, err))
+		return state, diags
+	}
+
+	req := resource.UpgradeStateRequest{
+		RawState: &rawState,
+		State:    &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorValue},
+	}
+	resp := &resource.UpgradeStateResponse{State: state}
+	upgrader.StateUpgrader(ctx, req, resp)
+	return resp.State, resp.Diagnostics
+}
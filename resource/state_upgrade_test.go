@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+type proxyModelV0 struct {
+	ID   types.String `tfsdk:"id"`
+	Port types.String `tfsdk:"port"`
+}
+
+type proxyModelV1 struct {
+	ID   types.String `tfsdk:"id"`
+	Port types.Int64  `tfsdk:"port"`
+}
+
+type proxyModelV2 struct {
+	ID      types.String `tfsdk:"id"`
+	Port    types.Int64  `tfsdk:"port"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func proxySchemaV0() resourceschema.Schema {
+	return resourceschema.Schema{Attributes: map[string]resourceschema.Attribute{
+		"id":   schema.ResourceStandardID(),
+		"port": schema.ResourceOptionalString("Proxy port"),
+	}}
+}
+
+func proxySchemaV1() resourceschema.Schema {
+	return resourceschema.Schema{Version: 1, Attributes: map[string]resourceschema.Attribute{
+		"id":   schema.ResourceStandardID(),
+		"port": schema.ResourcePortWithDefault("Proxy port", 8080),
+	}}
+}
+
+func proxySchemaV2() resourceschema.Schema {
+	return resourceschema.Schema{Version: 2, Attributes: map[string]resourceschema.Attribute{
+		"id":      schema.ResourceStandardID(),
+		"port":    schema.ResourcePortWithDefault("Proxy port", 8080),
+		"enabled": schema.ResourceOptionalBool("Whether the proxy is enabled"),
+	}}
+}
+
+func upgradeProxyV0(ctx context.Context, prior proxyModelV0) (proxyModelV1, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	port, err := strconv.ParseInt(prior.Port.ValueString(), 10, 64)
+	if err != nil {
+		diags.AddError("Invalid Port", err.Error())
+	}
+	return proxyModelV1{ID: prior.ID, Port: types.Int64Value(port)}, diags
+}
+
+func upgradeProxyV1(ctx context.Context, prior proxyModelV1) (proxyModelV2, diag.Diagnostics) {
+	return proxyModelV2{ID: prior.ID, Port: prior.Port, Enabled: types.BoolValue(true)}, nil
+}
+
+func proxyRegistry() *StateUpgradeRegistry {
+	return NewStateUpgradeRegistry(
+		StateUpgrade(1, proxySchemaV1(), upgradeProxyV1),
+		StateUpgrade(0, proxySchemaV0(), upgradeProxyV0),
+	)
+}
+
+func TestStateUpgradeRegistry_CurrentVersion(t *testing.T) {
+	registry := proxyRegistry()
+
+	if registry.CurrentVersion() != 2 {
+		t.Fatalf("CurrentVersion() = %d, expected 2", registry.CurrentVersion())
+	}
+	if err := registry.Validate(); err != nil {
+		t.Fatalf("Validate() returned unexpected error: %v", err)
+	}
+	if len(registry.UpgradeState(context.Background())) != 2 {
+		t.Fatal("UpgradeState should return an upgrader for each prior version")
+	}
+}
+
+func TestStateUpgradeRegistry_ChainsFromVersion0(t *testing.T) {
+	state, diags := UpgradeRawState(context.Background(), proxyRegistry(), proxySchemaV2(), 0, []byte(`{"id":"proxy","port":"3128"}`))
+	if diags.HasError() {
+		t.Fatalf("UpgradeRawState returned unexpected errors: %v", diags)
+	}
+
+	var model proxyModelV2
+	state.Get(context.Background(), &model)
+	if model.Port.ValueInt64() != 3128 || !model.Enabled.ValueBool() || model.ID.ValueString() != "proxy" {
+		t.Fatalf("upgraded state = %+v, expected port 3128, enabled and id 'proxy'", model)
+	}
+}
+
+func TestStateUpgradeRegistry_UpgradesFromVersion1(t *testing.T) {
+	state, diags := UpgradeRawState(context.Background(), proxyRegistry(), proxySchemaV2(), 1, []byte(`{"id":"proxy","port":8443}`))
+	if diags.HasError() {
+		t.Fatalf("UpgradeRawState returned unexpected errors: %v", diags)
+	}
+
+	var model proxyModelV2
+	state.Get(context.Background(), &model)
+	if model.Port.ValueInt64() != 8443 {
+		t.Fatalf("upgraded port = %d, expected 8443", model.Port.ValueInt64())
+	}
+}
+
+func TestStateUpgradeRegistry_TransformError(t *testing.T) {
+	_, diags := UpgradeRawState(context.Background(), proxyRegistry(), proxySchemaV2(), 0, []byte(`{"id":"proxy","port":"http"}`))
+	if !diags.HasError() {
+		t.Fatal("UpgradeRawState should return the transform error")
+	}
+}
+
+func TestStateUpgradeRegistry_UnknownVersion(t *testing.T) {
+	_, diags := UpgradeRawState(context.Background(), proxyRegistry(), proxySchemaV2(), 5, []byte(`{}`))
+	if !diags.HasError() {
+		t.Fatal("UpgradeRawState should fail for an unregistered version")
+	}
+}
+
+func TestStateUpgradeRegistry_ValidateMismatchedModels(t *testing.T) {
+	registry := NewStateUpgradeRegistry(
+		StateUpgrade(0, proxySchemaV0(), upgradeProxyV0),
+		StateUpgrade(1, proxySchemaV1(), func(ctx context.Context, prior proxyModelV0) (proxyModelV2, diag.Diagnostics) {
+			return proxyModelV2{}, nil
+		}),
+	)
+
+	err := registry.Validate()
+	if err == nil || !strings.Contains(err.Error(), "expects") {
+		t.Fatalf("Validate() = %v, expected a model mismatch error", err)
+	}
+
+	_, diags := UpgradeRawState(context.Background(), registry, proxySchemaV2(), 0, []byte(`{"id":"proxy","port":"1"}`))
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid State Upgrade Chain" {
+		t.Fatalf("UpgradeRawState diagnostics = %v, expected 'Invalid State Upgrade Chain'", diags)
+	}
+}
+
+func TestStateUpgradeRegistry_ValidateGap(t *testing.T) {
+	registry := NewStateUpgradeRegistry(
+		StateUpgrade(0, proxySchemaV0(), upgradeProxyV0),
+		StateUpgrade(2, proxySchemaV1(), upgradeProxyV1),
+	)
+
+	if err := registry.Validate(); err == nil {
+		t.Fatal("Validate() should fail when versions are not consecutive")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+// operationVerbs maps timeout operations to the verbs used in errors.TimeoutError
+var operationVerbs = map[string]string{
+	schema.TimeoutCreate: "creating",
+	schema.TimeoutRead:   "reading",
+	schema.TimeoutUpdate: "updating",
+	schema.TimeoutDelete: "deleting",
+}
+
+// operationVerb returns the verb for an operation, e.g. "creating" for "create"
+func operationVerb(operation string) string {
+	if verb, ok := operationVerbs[operation]; ok {
+		return verb
+	}
+	return operation
+}
+
+// OperationTimeout returns the duration configured for the operation in a timeouts object built with
+// schema.ResourceTimeouts, or defaultTimeout when the block or the operation is not set
+func OperationTimeout(timeouts types.Object, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultTimeout, diags
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, diags
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(schema.TimeoutsAttributeName).AtName(operation),
+			"Invalid Timeout",
+			fmt.Sprintf("Could not parse %s timeout %q: %v", operation, value.ValueString(), err),
+		)
+		return defaultTimeout, diags
+	}
+	return duration, diags
+}
+
+// WithOperationTimeout returns a context bounded by the operation timeout from the timeouts object.
+// Without a configured or default timeout the context is returned unchanged. The cancel function must always be called.
+func WithOperationTimeout(ctx context.Context, timeouts types.Object, operation string, defaultTimeout time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	timeout, diags := OperationTimeout(timeouts, operation, defaultTimeout)
+	if timeout <= 0 {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}
+
+// AddDeadlineDiagnostic adds the standard timeout diagnostic when ctx has hit its deadline and reports whether it did.
+// Use it before handling an API error so users see the TimeoutError message rather than a raw context error.
+func AddDeadlineDiagnostic(ctx context.Context, diags *diag.Diagnostics, operation string, resourceType string) bool {
+	if ctx.Err() != context.DeadlineExceeded {
+		return false
+	}
+	errors.AddTimeoutDiagnostic(diags, operationVerb(operation), resourceType)
+	return true
+}
+
+// attributeGetter is implemented by tfsdk.Config, tfsdk.Plan and tfsdk.State
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// operationContext masks registered secrets from logs and bounds ctx by the operation timeout when the schema defines a timeouts attribute
+func operationContext(ctx context.Context, hasTimeouts bool, data attributeGetter, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	ctx = redact.Context(ctx)
+	if !hasTimeouts {
+		return ctx, func() {}, nil
+	}
+	var timeouts types.Object
+	diags := data.GetAttribute(ctx, path.Root(schema.TimeoutsAttributeName), &timeouts)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel, timeoutDiags := WithOperationTimeout(ctx, timeouts, operation, 0)
+	diags.Append(timeoutDiags...)
+	return ctx, cancel, diags
+}
+
+// hasTimeouts reports whether the schema attributes include the timeouts attribute
+func hasTimeouts[T any](attributes map[string]T) bool {
+	_, ok := attributes[schema.TimeoutsAttributeName]
+	return ok
+}
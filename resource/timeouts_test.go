@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/schema"
+)
+
+func testTimeoutsObject(create string) types.Object {
+	attrTypes := map[string]attr.Type{"create": types.StringType, "delete": types.StringType}
+	createValue := types.StringNull()
+	if create != "" {
+		createValue = types.StringValue(create)
+	}
+	return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"create": createValue,
+		"delete": types.StringNull(),
+	})
+}
+
+func TestOperationTimeout(t *testing.T) {
+	timeout, diags := OperationTimeout(testTimeoutsObject("10m"), schema.TimeoutCreate, time.Minute)
+	if diags.HasError() || timeout != 10*time.Minute {
+		t.Fatalf("OperationTimeout = %s, %v, expected 10m", timeout, diags)
+	}
+
+	timeout, _ = OperationTimeout(testTimeoutsObject("10m"), schema.TimeoutDelete, time.Minute)
+	if timeout != time.Minute {
+		t.Fatalf("OperationTimeout for an unset operation = %s, expected the default", timeout)
+	}
+
+	timeout, _ = OperationTimeout(testTimeoutsObject("10m"), schema.TimeoutRead, time.Minute)
+	if timeout != time.Minute {
+		t.Fatalf("OperationTimeout for an operation not in the block = %s, expected the default", timeout)
+	}
+
+	timeout, _ = OperationTimeout(types.ObjectNull(nil), schema.TimeoutCreate, time.Minute)
+	if timeout != time.Minute {
+		t.Fatalf("OperationTimeout for a null block = %s, expected the default", timeout)
+	}
+}
+
+func TestOperationTimeout_Invalid(t *testing.T) {
+	_, diags := OperationTimeout(testTimeoutsObject("ten minutes"), schema.TimeoutCreate, time.Minute)
+	if !diags.HasError() {
+		t.Fatal("OperationTimeout should add an error for an invalid duration")
+	}
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	ctx, cancel, diags := WithOperationTimeout(context.Background(), testTimeoutsObject("5m"), schema.TimeoutCreate, 0)
+	defer cancel()
+
+	if diags.HasError() {
+		t.Fatalf("WithOperationTimeout returned unexpected errors: %v", diags)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 5*time.Minute {
+		t.Fatalf("WithOperationTimeout deadline = %v, %v, expected within 5m", deadline, ok)
+	}
+
+	ctx, cancel, _ = WithOperationTimeout(context.Background(), types.ObjectNull(nil), schema.TimeoutCreate, 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("WithOperationTimeout should not set a deadline without a timeout")
+	}
+}
+
+func TestAddDeadlineDiagnostic(t *testing.T) {
+	diags := diag.Diagnostics{}
+	if AddDeadlineDiagnostic(context.Background(), &diags, schema.TimeoutCreate, "repository") {
+		t.Fatal("AddDeadlineDiagnostic should return false for a live context")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	if !AddDeadlineDiagnostic(ctx, &diags, schema.TimeoutCreate, "repository") {
+		t.Fatal("AddDeadlineDiagnostic should return true after the deadline")
+	}
+	if diags.Errors()[0].Summary() != "Timeout creating" {
+		t.Fatalf("Error summary = %s, expected 'Timeout creating'", diags.Errors()[0].Summary())
+	}
+}
+
+type slowLifecycle struct{}
+
+func (l *slowLifecycle) CreateObject(ctx context.Context, plan *testTimeoutsModel) (*testObject, *http.Response, error) {
+	<-ctx.Done()
+	return nil, nil, ctx.Err()
+}
+
+func (l *slowLifecycle) ReadObject(ctx context.Context, state *testTimeoutsModel) (*testObject, *http.Response, error) {
+	return nil, nil, nil
+}
+
+func (l *slowLifecycle) UpdateObject(ctx context.Context, plan *testTimeoutsModel, state *testTimeoutsModel) (*testObject, *http.Response, error) {
+	return nil, nil, nil
+}
+
+func (l *slowLifecycle) DeleteObject(ctx context.Context, state *testTimeoutsModel) (*http.Response, error) {
+	return nil, nil
+}
+
+func (l *slowLifecycle) MapToModel(ctx context.Context, object *testObject, model *testTimeoutsModel) diag.Diagnostics {
+	return nil
+}
+
+type testTimeoutsModel struct {
+	ID       types.String `tfsdk:"id"`
+	Timeouts types.Object `tfsdk:"timeouts"`
+}
+
+func TestLifecycleCreate_TimeoutsBlock(t *testing.T) {
+	s := resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"id":                         resourceschema.StringAttribute{Computed: true},
+			schema.TimeoutsAttributeName: schema.ResourceTimeouts(),
+		},
+	}
+	objectType := s.Type().TerraformType(context.Background())
+	timeoutsType := objectType.(tftypes.Object).AttributeTypes[schema.TimeoutsAttributeName]
+	raw := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		schema.TimeoutsAttributeName: tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, "10ms"),
+			"read":   tftypes.NewValue(tftypes.String, nil),
+			"update": tftypes.NewValue(tftypes.String, nil),
+			"delete": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+
+	r := NewLifecycleResource[interface{}, interface{}, testTimeoutsModel, testObject]("repository", &slowLifecycle{})
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: raw}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Create should add an error when the create timeout is hit")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Timeout creating" {
+		t.Fatalf("Error summary = %s, expected 'Timeout creating'", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
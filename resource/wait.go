@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/errors"
+)
+
+const (
+	// DefaultMinPollInterval is the poll interval used when StateChangeConf.MinPollInterval is unset
+	DefaultMinPollInterval = 500 * time.Millisecond
+
+	// DefaultMaxPollInterval is the poll interval used when StateChangeConf.MaxPollInterval is unset
+	DefaultMaxPollInterval = 10 * time.Second
+)
+
+// StateRefreshFunc reads the current object and reports its state
+type StateRefreshFunc[T any] func(ctx context.Context) (result T, state string, err error)
+
+// StateChangeConf configures WaitFor to poll an eventually consistent API
+// until the object reaches one of the target states
+type StateChangeConf[T any] struct {
+	// Pending lists the states that are expected while waiting
+	Pending []string
+	// Target lists the states that end the wait
+	Target []string
+	// Refresh reads the current object and its state
+	Refresh StateRefreshFunc[T]
+	// Timeout is the maximum time to wait, see TimeoutFromSeconds
+	Timeout time.Duration
+	// MinPollInterval is the initial interval between refreshes; it doubles up to MaxPollInterval
+	MinPollInterval time.Duration
+	// MaxPollInterval caps the interval between refreshes; values below MinPollInterval poll at MinPollInterval
+	MaxPollInterval time.Duration
+	// ContinuousTargetOccurrence is the number of consecutive target reads required, defaults to 1
+	ContinuousTargetOccurrence int
+	// Operation and ResourceType are used in the timeout diagnostic, e.g. "creating" and "repository"
+	Operation    string
+	ResourceType string
+}
+
+// WaitFor polls conf.Refresh until a target state is reached and returns the last result.
+// On timeout a diagnostic built with errors.AddTimeoutDiagnostic is returned.
+func WaitFor[T any](ctx context.Context, conf StateChangeConf[T]) (T, diag.Diagnostics) {
+	return conf.WaitForState(ctx)
+}
+
+// WaitForState polls Refresh until a target state is reached and returns the last result
+func (conf StateChangeConf[T]) WaitForState(ctx context.Context) (T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var result T
+
+	interval := conf.MinPollInterval
+	if interval <= 0 {
+		interval = DefaultMinPollInterval
+	}
+	maxInterval := conf.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+	maxInterval = max(maxInterval, interval)
+	occurrences := max(conf.ContinuousTargetOccurrence, 1)
+
+	if conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, conf.Timeout)
+		defer cancel()
+	}
+
+	targetCount := 0
+	for {
+		current, state, err := conf.Refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				conf.addContextDiagnostic(ctx, &diags)
+				return result, diags
+			}
+			AddErrorf(&diags, fmt.Sprintf("Error waiting for %s", conf.ResourceType), "Could not refresh %s while %s: %v", conf.ResourceType, conf.Operation, err)
+			return result, diags
+		}
+		result = current
+
+		switch {
+		case slices.Contains(conf.Target, state):
+			targetCount++
+			if targetCount >= occurrences {
+				return result, diags
+			}
+		case slices.Contains(conf.Pending, state):
+			targetCount = 0
+		default:
+			AddErrorf(&diags, "Unexpected State",
+				"Unexpected state '%s' while %s %s, wanted target '%s'. Pending states: '%s'",
+				state, conf.Operation, conf.ResourceType, strings.Join(conf.Target, ", "), strings.Join(conf.Pending, ", "))
+			return result, diags
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			conf.addContextDiagnostic(ctx, &diags)
+			return result, diags
+		case <-timer.C:
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+// addContextDiagnostic reports a deadline as a timeout and any other context error as a cancellation
+func (conf StateChangeConf[T]) addContextDiagnostic(ctx context.Context, diags *diag.Diagnostics) {
+	if ctx.Err() == context.DeadlineExceeded {
+		errors.AddTimeoutDiagnostic(diags, conf.Operation, conf.ResourceType)
+		return
+	}
+	AddErrorf(diags, fmt.Sprintf("Error waiting for %s", conf.ResourceType), "Waiting for %s while %s was cancelled: %v", conf.ResourceType, conf.Operation, ctx.Err())
+}
+
+// TimeoutFromSeconds converts a ResourceTimeoutInt attribute value to a duration,
+// falling back to the default when the value is null, unknown or not positive
+func TimeoutFromSeconds(seconds types.Int64, defaultTimeout time.Duration) time.Duration {
+	if seconds.IsNull() || seconds.IsUnknown() || seconds.ValueInt64() <= 0 {
+		return defaultTimeout
+	}
+	return time.Duration(seconds.ValueInt64()) * time.Second
+}
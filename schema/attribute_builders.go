@@ -42,6 +42,7 @@ type stringAttributeConfig struct {
 
 // newResourceStringAttribute creates a resource string attribute from config
 func newResourceStringAttribute(config stringAttributeConfig) resourceschema.StringAttribute {
+	config = withSensitiveRegistrar(config)
 	attr := resourceschema.StringAttribute{
 		MarkdownDescription: config.description,
 		Required:            config.required,
@@ -63,6 +64,7 @@ func newResourceStringAttribute(config stringAttributeConfig) resourceschema.Str
 
 // newDataSourceStringAttribute creates a datasource string attribute from config
 func newDataSourceStringAttribute(config stringAttributeConfig) datasourceschema.StringAttribute {
+	config = withSensitiveRegistrar(config)
 	attr := datasourceschema.StringAttribute{
 		MarkdownDescription: config.description,
 		Required:            config.required,
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/server"
+)
+
+// ServerRequirement marks an attribute that only some servers support. Add it to the attribute's
+// validators, e.g. with ResourceOptionalStringWithValidators; TypedBaseResource checks it at plan
+// time against the server the provider is configured for. Validation only checks the marker itself.
+type ServerRequirement struct {
+	minVersion string
+	editions   []string
+}
+
+// SinceVersion marks an attribute that requires the given server version or later, e.g. "3.70.0"
+func SinceVersion(version string) ServerRequirement {
+	return ServerRequirement{minVersion: version}
+}
+
+// RequiresEdition marks an attribute that requires one of the given server editions, e.g. "PRO"
+func RequiresEdition(editions ...string) ServerRequirement {
+	return ServerRequirement{editions: editions}
+}
+
+// Description returns a plain text description of the requirement
+func (r ServerRequirement) Description(_ context.Context) string {
+	return r.String()
+}
+
+// MarkdownDescription returns a markdown description of the requirement
+func (r ServerRequirement) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+// String describes the requirement, e.g. "version 3.70.0 or later" or "the PRO edition"
+func (r ServerRequirement) String() string {
+	var parts []string
+	if r.minVersion != "" {
+		parts = append(parts, fmt.Sprintf("version %s or later", r.minVersion))
+	}
+	if len(r.editions) > 0 {
+		parts = append(parts, fmt.Sprintf("the %s edition", strings.Join(r.editions, " or ")))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// SatisfiedBy reports whether the server meets the requirement. An edition requirement is
+// considered met when the server reports no edition, since it cannot be verified.
+func (r ServerRequirement) SatisfiedBy(info *server.Info) (bool, error) {
+	if r.minVersion != "" {
+		minimum, err := server.ParseVersion(r.minVersion)
+		if err != nil {
+			return false, err
+		}
+		if !info.Version.AtLeast(minimum) {
+			return false, nil
+		}
+	}
+	if len(r.editions) > 0 && info.Edition != "" && !info.HasEdition(r.editions...) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// validate reports a marker that can never be checked, so that mistakes show up on the first validate
+func (r ServerRequirement) validate(attributePath path.Path, diags *diag.Diagnostics) {
+	if r.minVersion == "" && len(r.editions) == 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Server Requirement",
+			"The attribute has a server requirement without a version or edition. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if r.minVersion == "" {
+		return
+	}
+	if _, err := server.ParseVersion(r.minVersion); err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Server Requirement",
+			fmt.Sprintf("The attribute's server requirement is invalid: %s. Please report this issue to the provider developers.", err),
+		)
+	}
+}
+
+// ValidateString checks the marker
+func (r ServerRequirement) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateBool checks the marker
+func (r ServerRequirement) ValidateBool(_ context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateInt32 checks the marker
+func (r ServerRequirement) ValidateInt32(_ context.Context, req validator.Int32Request, resp *validator.Int32Response) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateInt64 checks the marker
+func (r ServerRequirement) ValidateInt64(_ context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateFloat64 checks the marker
+func (r ServerRequirement) ValidateFloat64(_ context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateList checks the marker
+func (r ServerRequirement) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateSet checks the marker
+func (r ServerRequirement) ValidateSet(_ context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateMap checks the marker
+func (r ServerRequirement) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
+
+// ValidateObject checks the marker
+func (r ServerRequirement) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	r.validate(req.Path, &resp.Diagnostics)
+}
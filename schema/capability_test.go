@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/server"
+)
+
+func TestServerRequirement_SatisfiedBy(t *testing.T) {
+	pro := &server.Info{Product: server.ProductNexusRepository, Version: server.Version{Major: 3, Minor: 70, Patch: 1}, Edition: server.EditionPro}
+	community := &server.Info{Product: server.ProductNexusRepository, Version: server.Version{Major: 3, Minor: 77}, Edition: server.EditionCommunity}
+	iq := &server.Info{Product: server.ProductIQServer, Version: server.Version{Major: 1, Minor: 185}}
+
+	tests := []struct {
+		name        string
+		requirement ServerRequirement
+		info        *server.Info
+		expected    bool
+	}{
+		{"version met", SinceVersion("3.70.0"), pro, true},
+		{"version not met", SinceVersion("3.71.0"), pro, false},
+		{"edition met", RequiresEdition("PRO"), pro, true},
+		{"edition ignores case", RequiresEdition("pro"), pro, true},
+		{"edition not met", RequiresEdition(server.EditionPro), community, false},
+		{"one of several editions", RequiresEdition(server.EditionPro, server.EditionCommunity), community, true},
+		{"unreported edition", RequiresEdition(server.EditionPro), iq, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			satisfied, err := tt.requirement.SatisfiedBy(tt.info)
+			if err != nil {
+				t.Fatalf("SatisfiedBy returned unexpected error: %v", err)
+			}
+			if satisfied != tt.expected {
+				t.Fatalf("SatisfiedBy(%s) = %t, expected %t", tt.info, satisfied, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerRequirement_String(t *testing.T) {
+	if actual := SinceVersion("3.70.0").String(); actual != "version 3.70.0 or later" {
+		t.Fatalf("String() = %q, expected 'version 3.70.0 or later'", actual)
+	}
+	if actual := RequiresEdition("PRO", "COMMUNITY").String(); actual != "the PRO or COMMUNITY edition" {
+		t.Fatalf("String() = %q, expected 'the PRO or COMMUNITY edition'", actual)
+	}
+}
+
+func TestServerRequirement_ValidatesMarker(t *testing.T) {
+	attr := ResourceOptionalStringWithValidators("content max age", SinceVersion("3.70.0"))
+	resp := &validator.StringResponse{}
+	attr.Validators[0].ValidateString(context.Background(), validator.StringRequest{Path: path.Root("content_max_age"), ConfigValue: types.StringValue("1440")}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ValidateString returned unexpected errors: %v", resp.Diagnostics)
+	}
+
+	for _, requirement := range []ServerRequirement{SinceVersion("latest"), RequiresEdition()} {
+		resp := &validator.BoolResponse{}
+		requirement.ValidateBool(context.Background(), validator.BoolRequest{Path: path.Root("enabled"), ConfigValue: types.BoolValue(true)}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("ValidateBool should reject the invalid marker %+v", requirement)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+)
+
+// ========================================
+// Resource Identity Schema Functions
+// ========================================
+
+// IdentityRequiredString returns a string identity attribute required for import by identity
+func IdentityRequiredString(description string) identityschema.StringAttribute {
+	return identityschema.StringAttribute{
+		Description:       description,
+		RequiredForImport: true,
+	}
+}
+
+// IdentityOptionalString returns a string identity attribute that is optional for import by identity
+func IdentityOptionalString(description string) identityschema.StringAttribute {
+	return identityschema.StringAttribute{
+		Description:       description,
+		OptionalForImport: true,
+	}
+}
+
+// IdentityRequiredInt64 returns an int64 identity attribute required for import by identity
+func IdentityRequiredInt64(description string) identityschema.Int64Attribute {
+	return identityschema.Int64Attribute{
+		Description:       description,
+		RequiredForImport: true,
+	}
+}
+
+// IdentityOptionalInt64 returns an int64 identity attribute that is optional for import by identity
+func IdentityOptionalInt64(description string) identityschema.Int64Attribute {
+	return identityschema.Int64Attribute{
+		Description:       description,
+		OptionalForImport: true,
+	}
+}
+
+// IdentityRequiredInt32 returns an int32 identity attribute required for import by identity
+func IdentityRequiredInt32(description string) identityschema.Int32Attribute {
+	return identityschema.Int32Attribute{
+		Description:       description,
+		RequiredForImport: true,
+	}
+}
+
+// IdentityOptionalInt32 returns an int32 identity attribute that is optional for import by identity
+func IdentityOptionalInt32(description string) identityschema.Int32Attribute {
+	return identityschema.Int32Attribute{
+		Description:       description,
+		OptionalForImport: true,
+	}
+}
+
+// IdentityRequiredBool returns a bool identity attribute required for import by identity
+func IdentityRequiredBool(description string) identityschema.BoolAttribute {
+	return identityschema.BoolAttribute{
+		Description:       description,
+		RequiredForImport: true,
+	}
+}
+
+// IdentityOptionalBool returns a bool identity attribute that is optional for import by identity
+func IdentityOptionalBool(description string) identityschema.BoolAttribute {
+	return identityschema.BoolAttribute{
+		Description:       description,
+		OptionalForImport: true,
+	}
+}
+
+// IdentityIDAttributes returns a map of identity attributes for resources identified by their id attribute
+func IdentityIDAttributes() map[string]identityschema.Attribute {
+	return map[string]identityschema.Attribute{
+		"id": IdentityRequiredString("Internal ID of the resource"),
+	}
+}
+
+// IdentityStringAttributes returns a map of required string identity attributes with the given names,
+// for resources identified by a compound key such as organization_id and application_id
+func IdentityStringAttributes(names ...string) map[string]identityschema.Attribute {
+	attributes := make(map[string]identityschema.Attribute, len(names))
+	for _, name := range names {
+		attributes[name] = IdentityRequiredString(name)
+	}
+	return attributes
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+)
+
+func TestIdentityStringAttributes(t *testing.T) {
+	if !IdentityRequiredString("test").IsRequiredForImport() {
+		t.Fatal("IdentityRequiredString should return attribute required for import")
+	}
+	if !IdentityOptionalString("test").IsOptionalForImport() {
+		t.Fatal("IdentityOptionalString should return attribute optional for import")
+	}
+}
+
+func TestIdentityNumberAndBoolAttributes(t *testing.T) {
+	if !IdentityRequiredInt64("test").IsRequiredForImport() {
+		t.Fatal("IdentityRequiredInt64 should return attribute required for import")
+	}
+	if !IdentityOptionalInt64("test").IsOptionalForImport() {
+		t.Fatal("IdentityOptionalInt64 should return attribute optional for import")
+	}
+	if !IdentityRequiredInt32("test").IsRequiredForImport() {
+		t.Fatal("IdentityRequiredInt32 should return attribute required for import")
+	}
+	if !IdentityOptionalInt32("test").IsOptionalForImport() {
+		t.Fatal("IdentityOptionalInt32 should return attribute optional for import")
+	}
+	if !IdentityRequiredBool("test").IsRequiredForImport() {
+		t.Fatal("IdentityRequiredBool should return attribute required for import")
+	}
+	if !IdentityOptionalBool("test").IsOptionalForImport() {
+		t.Fatal("IdentityOptionalBool should return attribute optional for import")
+	}
+}
+
+func TestIdentityIDAttributes(t *testing.T) {
+	attrs := IdentityIDAttributes()
+	if _, ok := attrs["id"]; !ok {
+		t.Fatal("IdentityIDAttributes should contain an id attribute")
+	}
+
+	s := identityschema.Schema{Attributes: attrs}
+	if diags := s.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("IdentityIDAttributes should produce a valid identity schema: %v", diags)
+	}
+}
+
+func TestIdentityStringAttributesFromNames(t *testing.T) {
+	attrs := IdentityStringAttributes("organization_id", "application_id")
+	if len(attrs) != 2 {
+		t.Fatalf("IdentityStringAttributes should return 2 attributes, got %d", len(attrs))
+	}
+	if !attrs["organization_id"].IsRequiredForImport() {
+		t.Fatal("IdentityStringAttributes should return attributes required for import")
+	}
+}
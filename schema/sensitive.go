@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+// SensitiveValueRegistrar registers the values of a sensitive attribute with the redaction registry.
+// It is both a validator, to see configured values, and a plan modifier, to see values held in state.
+type SensitiveValueRegistrar struct{}
+
+// RegisterSensitiveValue returns a validator and plan modifier that registers attribute values with redact.Default.
+// The Sensitive builders add it automatically; add it yourself to sensitive attributes built by hand.
+func RegisterSensitiveValue() SensitiveValueRegistrar {
+	return SensitiveValueRegistrar{}
+}
+
+// Description returns a plain text description of the registrar
+func (r SensitiveValueRegistrar) Description(_ context.Context) string {
+	return "value is redacted from diagnostics and logs"
+}
+
+// MarkdownDescription returns a markdown description of the registrar
+func (r SensitiveValueRegistrar) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+// ValidateString registers the configured value
+func (r SensitiveValueRegistrar) ValidateString(_ context.Context, req validator.StringRequest, _ *validator.StringResponse) {
+	registerString(req.ConfigValue)
+}
+
+// PlanModifyString registers the configured, planned and prior state values without changing the plan
+func (r SensitiveValueRegistrar) PlanModifyString(_ context.Context, req planmodifier.StringRequest, _ *planmodifier.StringResponse) {
+	registerString(req.ConfigValue)
+	registerString(req.PlanValue)
+	registerString(req.StateValue)
+}
+
+// registerString adds a known string value to the redaction registry
+func registerString(value types.String) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	redact.Add(value.ValueString())
+}
+
+// withSensitiveRegistrar adds the registrar to the validators and plan modifiers of a sensitive attribute config
+func withSensitiveRegistrar(config stringAttributeConfig) stringAttributeConfig {
+	if !config.sensitive {
+		return config
+	}
+	config.validators = append(append([]validator.String{}, config.validators...), RegisterSensitiveValue())
+	config.planModifiers = append(append([]planmodifier.String{}, config.planModifiers...), RegisterSensitiveValue())
+	return config
+}
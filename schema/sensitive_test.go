@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/redact"
+)
+
+func TestSensitiveBuildersRegisterValues(t *testing.T) {
+	t.Cleanup(redact.Default.Reset)
+
+	attr := ResourceSensitiveRequiredString("password")
+	if len(attr.Validators) != 1 || len(attr.PlanModifiers) != 1 {
+		t.Fatal("ResourceSensitiveRequiredString should register its value with the redaction registry")
+	}
+	attr.Validators[0].ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue("config-secret")}, &validator.StringResponse{})
+	attr.PlanModifiers[0].PlanModifyString(context.Background(), planmodifier.StringRequest{
+		ConfigValue: types.StringNull(),
+		PlanValue:   types.StringUnknown(),
+		StateValue:  types.StringValue("state-secret"),
+	}, &planmodifier.StringResponse{})
+
+	if actual := redact.String("config-secret state-secret"); actual != "*** ***" {
+		t.Fatalf("redact.String() = %q, expected both values to be registered", actual)
+	}
+}
+
+func TestSensitiveBuildersKeepPlanModifiers(t *testing.T) {
+	planMods := []planmodifier.String{RegisterSensitiveValue()}
+	attr := ResourceSensitiveOptionalStringWithPlanModifier("password", planMods...)
+	if len(attr.PlanModifiers) != 2 || len(planMods) != 1 {
+		t.Fatal("ResourceSensitiveOptionalStringWithPlanModifier should append the registrar without changing the caller's plan modifiers")
+	}
+}
+
+func TestNonSensitiveBuildersDoNotRegister(t *testing.T) {
+	if attr := ResourceRequiredString("name"); attr.Validators != nil || attr.PlanModifiers != nil {
+		t.Fatal("ResourceRequiredString should not register its value with the redaction registry")
+	}
+	if attr := DataSourceSensitiveString("token"); len(attr.Validators) != 1 {
+		t.Fatal("DataSourceSensitiveString should register its value with the redaction registry")
+	}
+}
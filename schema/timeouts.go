@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"fmt"
+
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	"github.com/sonatype-nexus-community/terraform-provider-shared/validators"
+)
+
+// TimeoutsAttributeName is the name of the standard timeouts attribute
+const TimeoutsAttributeName = "timeouts"
+
+// Timeout operation names used as attributes of the timeouts block
+const (
+	TimeoutCreate = "create"
+	TimeoutRead   = "read"
+	TimeoutUpdate = "update"
+	TimeoutDelete = "delete"
+)
+
+// ResourceTimeouts returns an optional timeouts nested attribute with create, read, update and delete durations
+func ResourceTimeouts() resourceschema.SingleNestedAttribute {
+	return ResourceTimeoutsFor(TimeoutCreate, TimeoutRead, TimeoutUpdate, TimeoutDelete)
+}
+
+// ResourceTimeoutsFor returns an optional timeouts nested attribute for the given operations only
+func ResourceTimeoutsFor(operations ...string) resourceschema.SingleNestedAttribute {
+	attributes := make(map[string]resourceschema.Attribute, len(operations))
+	for _, operation := range operations {
+		attributes[operation] = ResourceOptionalStringWithValidators(
+			fmt.Sprintf("Timeout for %s operations, as a duration such as `30s` or `10m`", operation),
+			validators.DurationValidator(),
+		)
+	}
+	return ResourceOptionalSingleNestedAttribute("Timeouts for resource operations", attributes)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestResourceTimeouts(t *testing.T) {
+	attr := ResourceTimeouts()
+	if !attr.IsOptional() {
+		t.Fatal("ResourceTimeouts should return optional attribute")
+	}
+	for _, operation := range []string{TimeoutCreate, TimeoutRead, TimeoutUpdate, TimeoutDelete} {
+		nested, ok := attr.Attributes[operation]
+		if !ok {
+			t.Fatalf("ResourceTimeouts should contain a %s attribute", operation)
+		}
+		if !nested.IsOptional() {
+			t.Fatalf("ResourceTimeouts %s attribute should be optional", operation)
+		}
+	}
+}
+
+func TestResourceTimeoutsFor(t *testing.T) {
+	attr := ResourceTimeoutsFor(TimeoutCreate, TimeoutDelete)
+	if len(attr.Attributes) != 2 {
+		t.Fatalf("ResourceTimeoutsFor should return 2 attributes, got %d", len(attr.Attributes))
+	}
+	if _, ok := attr.Attributes[TimeoutRead]; ok {
+		t.Fatal("ResourceTimeoutsFor should not contain operations that were not requested")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
@@ -181,6 +182,10 @@ func ResourceOptionalSensitiveStringWithLengthAtLeast(description string, minLen
 		Sensitive:           true,
 		Validators: []validator.String{
 			stringvalidator.LengthAtLeast(minLength),
+			RegisterSensitiveValue(),
+		},
+		PlanModifiers: []planmodifier.String{
+			RegisterSensitiveValue(),
 		},
 	}
 }
@@ -193,6 +198,10 @@ func ResourceRequiredSensitiveStringWithLengthAtLeast(description string, minLen
 		Sensitive:           true,
 		Validators: []validator.String{
 			stringvalidator.LengthAtLeast(minLength),
+			RegisterSensitiveValue(),
+		},
+		PlanModifiers: []planmodifier.String{
+			RegisterSensitiveValue(),
 		},
 	}
 }
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// ProductNexusRepository is the product name of Sonatype Nexus Repository
+	ProductNexusRepository = "Nexus Repository"
+	// ProductIQServer is the product name of Sonatype IQ Server
+	ProductIQServer = "IQ Server"
+
+	// EditionPro is the licensed edition of Nexus Repository
+	EditionPro = "PRO"
+	// EditionCommunity is the free edition of Nexus Repository from 3.77.0
+	EditionCommunity = "COMMUNITY"
+	// EditionOSS is the free edition of Nexus Repository before 3.77.0
+	EditionOSS = "OSS"
+)
+
+// Info describes the server a provider is configured against
+type Info struct {
+	Product string
+	Version Version
+	// Edition is the edition in upper case, or empty when the product does not report one
+	Edition string
+}
+
+// HasEdition reports whether the server runs one of the editions, ignoring case
+func (i *Info) HasEdition(editions ...string) bool {
+	for _, edition := range editions {
+		if strings.EqualFold(i.Edition, edition) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the server, e.g. "Nexus Repository 3.70.1-02 (PRO)"
+func (i *Info) String() string {
+	description := strings.TrimSpace(i.Product + " " + i.Version.String())
+	if i.Edition != "" {
+		description += " (" + i.Edition + ")"
+	}
+	return description
+}
+
+// Source reports the server version and edition
+type Source interface {
+	Info(ctx context.Context) (*Info, error)
+}
+
+// DetectFunc asks the server at baseURL for its version and edition
+type DetectFunc func(ctx context.Context, httpClient *http.Client, baseURL string) (*Info, error)
+
+// Detector detects the server version and edition on first use and caches the result, so that
+// every resource sharing the provider configuration causes at most one request
+type Detector struct {
+	detect     DetectFunc
+	httpClient *http.Client
+	baseURL    string
+
+	mu   sync.Mutex
+	done bool
+	info *Info
+	err  error
+}
+
+// NewDetector creates a detector for the server at baseURL. Create one in the provider's Configure
+// and hand it to resources through TypedBaseResourceConfig.Server.
+func NewDetector(detect DetectFunc, httpClient *http.Client, baseURL string) *Detector {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Detector{detect: detect, httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Info returns the cached server information, detecting it on the first call. Failures are cached
+// too, except when ctx was cancelled before detection finished.
+func (d *Detector) Info(ctx context.Context) (*Info, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.done {
+		return d.info, d.err
+	}
+
+	info, err := d.detect(ctx, d.httpClient, d.baseURL)
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	d.done, d.info, d.err = true, info, err
+	return info, err
+}
+
+// Static is a Source that always returns the same information, e.g. in tests
+type Static Info
+
+// Info returns the information
+func (s Static) Info(_ context.Context) (*Info, error) {
+	info := Info(s)
+	return &info, nil
+}
+
+// nexusServerHeader matches Server headers such as "Nexus/3.70.1-02 (PRO)"
+var nexusServerHeader = regexp.MustCompile(`Nexus/([0-9][0-9A-Za-z.\-]*)(?:\s+\(([A-Za-z]+)\))?`)
+
+// ParseNexusServerHeader reads the version and edition from a Nexus Repository Server header
+func ParseNexusServerHeader(header string) (*Info, error) {
+	match := nexusServerHeader.FindStringSubmatch(header)
+	if match == nil {
+		return nil, fmt.Errorf("unrecognised Server header %q", header)
+	}
+	version, err := ParseVersion(match[1])
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Product: ProductNexusRepository, Version: version, Edition: strings.ToUpper(match[2])}, nil
+}
+
+// DetectNexusRepository reads the version and edition from the Server header of the status endpoint.
+// It fails when the header has been disabled on the server.
+func DetectNexusRepository(ctx context.Context, httpClient *http.Client, baseURL string) (*Info, error) {
+	httpResponse, err := get(ctx, httpClient, baseURL+"/service/rest/v1/status")
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	header := httpResponse.Header.Get("Server")
+	if header == "" {
+		return nil, fmt.Errorf("detecting server version: the response has no Server header, which may be disabled on the server")
+	}
+	return ParseNexusServerHeader(header)
+}
+
+// DetectIQServer reads the version from the product version endpoint. IQ Server reports no edition.
+func DetectIQServer(ctx context.Context, httpClient *http.Client, baseURL string) (*Info, error) {
+	httpResponse, err := get(ctx, httpClient, baseURL+"/rest/product/version")
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	var body struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(io.LimitReader(httpResponse.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("reading IQ Server version: %w", err)
+	}
+	version, err := ParseVersion(body.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Product: ProductIQServer, Version: version}, nil
+}
+
+// get sends a GET request and fails on any status other than 200
+func get(ctx context.Context, httpClient *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("detecting server version: %w", err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		httpResponse.Body.Close()
+		return nil, fmt.Errorf("detecting server version: GET %s returned %s", url, httpResponse.Status)
+	}
+	return httpResponse, nil
+}
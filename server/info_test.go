@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseNexusServerHeader(t *testing.T) {
+	tests := map[string]string{
+		"Nexus/3.70.1-02 (PRO)":       "Nexus Repository 3.70.1-02 (PRO)",
+		"Nexus/3.37.3-02 (OSS)":       "Nexus Repository 3.37.3-02 (OSS)",
+		"Nexus/3.77.0-08 (COMMUNITY)": "Nexus Repository 3.77.0-08 (COMMUNITY)",
+		"Nexus/3.68.0-04":             "Nexus Repository 3.68.0-04",
+	}
+	for header, expected := range tests {
+		info, err := ParseNexusServerHeader(header)
+		if err != nil {
+			t.Fatalf("ParseNexusServerHeader(%q) returned unexpected error: %v", header, err)
+		}
+		if info.String() != expected {
+			t.Fatalf("ParseNexusServerHeader(%q) = %s, expected %s", header, info, expected)
+		}
+	}
+
+	if _, err := ParseNexusServerHeader("Jetty(9.4)"); err == nil {
+		t.Fatal("ParseNexusServerHeader should reject other servers")
+	}
+}
+
+func TestDetectNexusRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/service/rest/v1/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Server", "Nexus/3.70.1-02 (PRO)")
+	}))
+	t.Cleanup(server.Close)
+
+	info, err := DetectNexusRepository(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("DetectNexusRepository returned unexpected error: %v", err)
+	}
+	if info.Product != ProductNexusRepository || !info.HasEdition("pro") || info.Version.Minor != 70 {
+		t.Fatalf("DetectNexusRepository() = %+v, unexpected values", info)
+	}
+}
+
+func TestDetectIQServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/product/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"tag": "abc", "version": "1.185.0-01", "build": "build-number"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	info, err := DetectIQServer(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("DetectIQServer returned unexpected error: %v", err)
+	}
+	if info.String() != "IQ Server 1.185.0-01" {
+		t.Fatalf("DetectIQServer() = %s, expected 'IQ Server 1.185.0-01'", info)
+	}
+
+	if _, err := DetectIQServer(context.Background(), server.Client(), server.URL+"/missing"); err == nil {
+		t.Fatal("DetectIQServer should fail on a 404")
+	}
+}
+
+func TestDetector_CachesResult(t *testing.T) {
+	var calls atomic.Int32
+	detector := NewDetector(func(ctx context.Context, httpClient *http.Client, baseURL string) (*Info, error) {
+		calls.Add(1)
+		if baseURL != "https://nexus.example.com" {
+			return nil, fmt.Errorf("unexpected base URL %s", baseURL)
+		}
+		return &Info{Product: ProductNexusRepository, Version: Version{Major: 3, Minor: 70}}, nil
+	}, nil, "https://nexus.example.com/")
+
+	for i := 0; i < 3; i++ {
+		if _, err := detector.Info(context.Background()); err != nil {
+			t.Fatalf("Info returned unexpected error: %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("detect called %d times, expected 1", calls.Load())
+	}
+}
+
+func TestDetector_RetriesAfterCancellation(t *testing.T) {
+	var calls atomic.Int32
+	detector := NewDetector(func(ctx context.Context, httpClient *http.Client, baseURL string) (*Info, error) {
+		calls.Add(1)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return &Info{}, nil
+	}, nil, "https://nexus.example.com")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := detector.Info(ctx); err == nil {
+		t.Fatal("Info should fail with a cancelled context")
+	}
+	if _, err := detector.Info(context.Background()); err != nil {
+		t.Fatalf("Info returned unexpected error after cancellation: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("detect called %d times, expected 2", calls.Load())
+	}
+}
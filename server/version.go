@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package server detects the version and edition of the Sonatype server a provider talks to
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a Sonatype release number such as 3.70.1-02, where 02 is the build
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Build is the build suffix, such as "02"; it is ignored when comparing versions
+	Build string
+}
+
+// ParseVersion parses versions such as "3.70", "3.70.1" and "3.70.1-02"
+func ParseVersion(value string) (Version, error) {
+	core, build, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(value), "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q, expected major.minor.patch", value)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, fmt.Errorf("invalid version %q, expected major.minor.patch", value)
+		}
+		numbers[i] = number
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Build: build}, nil
+}
+
+// Compare returns -1, 0 or 1 when v is older than, the same release as, or newer than other
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is the same release as or newer than minimum
+func (v Version) AtLeast(minimum Version) bool {
+	return v.Compare(minimum) >= 0
+}
+
+// IsZero reports whether the version is unset
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// String formats the version as major.minor.patch with the build suffix, if any
+func (v Version) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Build != "" {
+		version += "-" + v.Build
+	}
+	return version
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+		valid    bool
+	}{
+		{"3.70.1-02", Version{Major: 3, Minor: 70, Patch: 1, Build: "02"}, true},
+		{"3.70", Version{Major: 3, Minor: 70}, true},
+		{"v1.185.0", Version{Major: 1, Minor: 185}, true},
+		{"3", Version{}, false},
+		{"3.x.1", Version{}, false},
+		{"", Version{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, err := ParseVersion(tt.input)
+			if (err == nil) != tt.valid {
+				t.Fatalf("ParseVersion(%q) error = %v, expected valid: %t", tt.input, err, tt.valid)
+			}
+			if actual != tt.expected {
+				t.Fatalf("ParseVersion(%q) = %+v, expected %+v", tt.input, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	parse := func(value string) Version {
+		version, err := ParseVersion(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return version
+	}
+
+	if !parse("3.70.0-01").AtLeast(parse("3.70.0")) {
+		t.Fatal("the build suffix should not affect comparison")
+	}
+	if parse("3.9.0").AtLeast(parse("3.70.0")) {
+		t.Fatal("3.9.0 should be older than 3.70.0")
+	}
+	if parse("4.0.0").Compare(parse("3.99.9")) != 1 || parse("3.70.1").Compare(parse("3.70.2")) != -1 {
+		t.Fatal("Compare returned unexpected results")
+	}
+	if parse("3.70.1-02").String() != "3.70.1-02" || !(Version{}).IsZero() {
+		t.Fatal("String or IsZero returned unexpected results")
+	}
+}
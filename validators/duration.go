@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// durationValidator validates that a string is a positive Go duration such as "30s" or "10m"
+type durationValidator struct{}
+
+// DurationValidator returns a string validator that ensures the value is a positive duration such as "30s", "10m" or "1h30m"
+func DurationValidator() validator.String {
+	return durationValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as \"30s\", \"10m\" or \"1h30m\""
+}
+
+// MarkdownDescription returns a markdown description of the validator's behavior
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration such as `30s`, `10m` or `1h30m`"
+}
+
+// ValidateString performs the validation
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q (%v)", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -17,9 +17,12 @@
 package validators
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestStringOneOfValidator(t *testing.T) {
@@ -61,3 +64,31 @@ func TestStringOneOfValidator_SingleValue(t *testing.T) {
 		t.Fatal("StringOneOfValidator should return a non-nil validator")
 	}
 }
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		value     types.String
+		expectErr bool
+	}{
+		{value: types.StringValue("30s")},
+		{value: types.StringValue("10m")},
+		{value: types.StringValue("1h30m")},
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+		{value: types.StringValue("10"), expectErr: true},
+		{value: types.StringValue("soon"), expectErr: true},
+		{value: types.StringValue("-5m"), expectErr: true},
+		{value: types.StringValue("0s"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		req := validator.StringRequest{Path: path.Root("create"), ConfigValue: tt.value}
+		resp := &validator.StringResponse{}
+
+		DurationValidator().ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != tt.expectErr {
+			t.Fatalf("DurationValidator(%s) error = %v, expected error = %v", tt.value, resp.Diagnostics.HasError(), tt.expectErr)
+		}
+	}
+}